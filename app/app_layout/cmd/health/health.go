@@ -0,0 +1,408 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/config/file"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport/grpc"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jeffinity/singularity/nacosx"
+	perr "github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/jeffinity/app-layout/app/app_layout/cmd/server"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/app_init"
+	healthv1 "github.com/jeffinity/app-layout/pkg/health"
+)
+
+// 退出码，可直接用于容器 HEALTHCHECK（2 为 docker 保留值，不使用）
+const (
+	ExitUp       = 0
+	ExitDown     = 1 // DOWN、探测失败或实例不可达
+	ExitDegraded = 3
+)
+
+const (
+	protocolGRPC = "grpc"
+	protocolHTTP = "http"
+
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+var (
+	flagAddr       string
+	flagProtocol   string
+	flagProbe      string
+	flagOutput     string
+	flagTimeout    time.Duration
+	flagDiscover   bool
+	flagConf       string
+	flagInstance   string
+	flagNames      []string
+	flagSkipSlow   bool
+	flagVerbose    bool
+	flagDegradedOK bool
+)
+
+func CmdHealth() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "health",
+		Short: "查询运行中实例的健康状态（退出码：0 UP / 1 DOWN / 3 DEGRADED）",
+		Example: `  app_layout health --addr 127.0.0.1:7401 -p grpc
+  app_layout health --addr 127.0.0.1:7301 -c readiness
+  app_layout health --discover --conf ./config.yaml -o json`,
+		Run: func(cmd *cobra.Command, args []string) {
+			os.Exit(run(cmd.Context()))
+		},
+	}
+	cmd.Flags().StringVar(&flagAddr, "addr", "", "实例地址 host:port，未使用 --discover 时必填")
+	cmd.Flags().StringVarP(&flagProtocol, "protocol", "p", protocolHTTP, "协议：grpc / http")
+	cmd.Flags().StringVarP(&flagProbe, "check", "c", "status", "检查类型：status / liveness / readiness / startup")
+	cmd.Flags().StringVarP(&flagOutput, "output", "o", outputTable, "输出格式：table / json")
+	cmd.Flags().DurationVar(&flagTimeout, "timeout", 3*time.Second, "单个实例的请求超时")
+	cmd.Flags().BoolVar(&flagDiscover, "discover", false, "通过 Nacos 发现实例（读取 --conf 中的 nacos 配置）")
+	cmd.Flags().StringVar(&flagConf, "conf", "./config.yaml", "配置文件路径，--discover 时使用")
+	cmd.Flags().StringVar(&flagInstance, "instance", "", "--discover 时仅检查该 pc_id 的实例，默认检查全部实例")
+	cmd.Flags().StringSliceVar(&flagNames, "names", nil, "仅返回这些检查项（status）")
+	cmd.Flags().BoolVar(&flagSkipSlow, "skip-slow", false, "跳过慢检查项（status）")
+	cmd.Flags().BoolVar(&flagVerbose, "verbose", false, "返回检查项详细信息与运行时信息（status）")
+	cmd.Flags().BoolVar(&flagDegradedOK, "degraded-ok", false, "DEGRADED 时以 0 退出")
+	return cmd
+}
+
+// result 单个实例的检查结果
+type result struct {
+	Endpoint string
+	Overall  healthv1.Status
+	Reply    *healthv1.StatusReply // 仅 status
+	Err      error
+}
+
+func run(ctx context.Context) int {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if flagProtocol != protocolGRPC && flagProtocol != protocolHTTP {
+		return fail(perr.Errorf("unsupported protocol %q", flagProtocol))
+	}
+	if flagOutput != outputTable && flagOutput != outputJSON {
+		return fail(perr.Errorf("unsupported output %q", flagOutput))
+	}
+
+	endpoints, err := resolveEndpoints(ctx)
+	if err != nil {
+		return fail(err)
+	}
+
+	results := make([]*result, 0, len(endpoints))
+	for _, ep := range endpoints {
+		results = append(results, query(ctx, ep))
+	}
+
+	if flagOutput == outputJSON {
+		printJSON(os.Stdout, results)
+	} else {
+		printTables(os.Stdout, results)
+	}
+	return exitCode(results)
+}
+
+func fail(err error) int {
+	_, _ = fmt.Fprintf(os.Stderr, "health: %v\n", err)
+	return ExitDown
+}
+
+func resolveEndpoints(ctx context.Context) ([]string, error) {
+	if !flagDiscover {
+		if flagAddr == "" {
+			return nil, perr.New("--addr is required unless --discover is set")
+		}
+		return []string{flagAddr}, nil
+	}
+
+	bc, err := app_init.LoadConf(file.NewSource(flagConf))
+	if err != nil {
+		return nil, perr.WithMessage(err, "load config failed:")
+	}
+	nr, err := nacosx.NewRegistryEngineSimple(app_init.NewNacosConf(bc))
+	if err != nil {
+		return nil, perr.WithMessage(err, "create nacos registry failed:")
+	}
+	if nr == nil {
+		return nil, perr.New("nacos is not configured")
+	}
+
+	instances, err := nr.GetService(ctx, server.ServiceName+"."+flagProtocol)
+	if err != nil {
+		return nil, perr.WithMessage(err, "discover instances failed:")
+	}
+	var endpoints []string
+	for _, ins := range instances {
+		if flagInstance != "" && ins.Metadata["pc_id"] != flagInstance {
+			continue
+		}
+		for _, ep := range ins.Endpoints {
+			u, err := url.Parse(ep)
+			if err != nil {
+				continue
+			}
+			endpoints = append(endpoints, u.Host)
+		}
+	}
+	if len(endpoints) == 0 {
+		return nil, perr.Errorf("no %s instance of %s found in nacos", flagProtocol, server.ServiceName)
+	}
+	return endpoints, nil
+}
+
+// healthClient 统一 gRPC / HTTP 两种客户端
+type healthClient interface {
+	Liveness(ctx context.Context, in *healthv1.HealthCheckRequest) (*emptypb.Empty, error)
+	Readiness(ctx context.Context, in *healthv1.HealthCheckRequest) (*emptypb.Empty, error)
+	Startup(ctx context.Context, in *healthv1.HealthCheckRequest) (*emptypb.Empty, error)
+	Status(ctx context.Context, in *healthv1.StatusRequest) (*healthv1.StatusReply, error)
+}
+
+type grpcClient struct {
+	cli healthv1.HealthServiceClient
+}
+
+func (c grpcClient) Liveness(ctx context.Context, in *healthv1.HealthCheckRequest) (*emptypb.Empty, error) {
+	return c.cli.Liveness(ctx, in)
+}
+
+func (c grpcClient) Readiness(ctx context.Context, in *healthv1.HealthCheckRequest) (*emptypb.Empty, error) {
+	return c.cli.Readiness(ctx, in)
+}
+
+func (c grpcClient) Startup(ctx context.Context, in *healthv1.HealthCheckRequest) (*emptypb.Empty, error) {
+	return c.cli.Startup(ctx, in)
+}
+
+func (c grpcClient) Status(ctx context.Context, in *healthv1.StatusRequest) (*healthv1.StatusReply, error) {
+	return c.cli.Status(ctx, in)
+}
+
+type httpClient struct {
+	cli healthv1.HealthServiceHTTPClient
+}
+
+func (c httpClient) Liveness(ctx context.Context, in *healthv1.HealthCheckRequest) (*emptypb.Empty, error) {
+	return c.cli.Liveness(ctx, in)
+}
+
+func (c httpClient) Readiness(ctx context.Context, in *healthv1.HealthCheckRequest) (*emptypb.Empty, error) {
+	return c.cli.Readiness(ctx, in)
+}
+
+func (c httpClient) Startup(ctx context.Context, in *healthv1.HealthCheckRequest) (*emptypb.Empty, error) {
+	return c.cli.Startup(ctx, in)
+}
+
+func (c httpClient) Status(ctx context.Context, in *healthv1.StatusRequest) (*healthv1.StatusReply, error) {
+	return c.cli.Status(ctx, in)
+}
+
+func dial(ctx context.Context, endpoint string) (healthClient, func(), error) {
+	if flagProtocol == protocolGRPC {
+		conn, err := grpc.DialInsecure(ctx, grpc.WithEndpoint(endpoint), grpc.WithTimeout(flagTimeout))
+		if err != nil {
+			return nil, nil, err
+		}
+		return grpcClient{healthv1.NewHealthServiceClient(conn)}, func() { _ = conn.Close() }, nil
+	}
+
+	cli, err := http.NewClient(ctx, http.WithEndpoint("http://"+endpoint), http.WithTimeout(flagTimeout))
+	if err != nil {
+		return nil, nil, err
+	}
+	return httpClient{healthv1.NewHealthServiceHTTPClient(cli)}, func() { _ = cli.Close() }, nil
+}
+
+func query(ctx context.Context, endpoint string) *result {
+	res := &result{Endpoint: endpoint, Overall: healthv1.Status_STATUS_DOWN}
+	cli, closeFn, err := dial(ctx, endpoint)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	defer closeFn()
+
+	ctx, cancel := context.WithTimeout(ctx, flagTimeout)
+	defer cancel()
+
+	probe := func(fn func(context.Context, *healthv1.HealthCheckRequest) (*emptypb.Empty, error)) {
+		if _, err := fn(ctx, &healthv1.HealthCheckRequest{}); err != nil {
+			res.Err = err
+			return
+		}
+		res.Overall = healthv1.Status_STATUS_UP
+	}
+
+	switch flagProbe {
+	case "liveness":
+		probe(cli.Liveness)
+	case "readiness":
+		probe(cli.Readiness)
+	case "startup":
+		probe(cli.Startup)
+	case "status":
+		reply, err := cli.Status(ctx, &healthv1.StatusRequest{
+			Names:    flagNames,
+			SkipSlow: flagSkipSlow,
+			Verbose:  flagVerbose,
+		})
+		if err != nil {
+			res.Err = err
+			return res
+		}
+		res.Reply = reply
+		res.Overall = reply.GetOverall()
+	default:
+		res.Err = perr.Errorf("unsupported check %q", flagProbe)
+	}
+	return res
+}
+
+func exitCode(results []*result) int {
+	code := ExitUp
+	for _, res := range results {
+		switch res.Overall {
+		case healthv1.Status_STATUS_UP:
+		case healthv1.Status_STATUS_DEGRADED:
+			if !flagDegradedOK {
+				code = ExitDegraded
+			}
+		default:
+			return ExitDown
+		}
+	}
+	return code
+}
+
+func printJSON(w io.Writer, results []*result) {
+	type item struct {
+		Endpoint string          `json:"endpoint"`
+		Check    string          `json:"check"`
+		Overall  string          `json:"overall"`
+		Error    string          `json:"error,omitempty"`
+		Status   json.RawMessage `json:"status,omitempty"`
+	}
+
+	items := make([]item, 0, len(results))
+	for _, res := range results {
+		it := item{Endpoint: res.Endpoint, Check: flagProbe, Overall: statusText(res.Overall)}
+		if res.Err != nil {
+			it.Error = errors.FromError(res.Err).GetMessage()
+		}
+		if res.Reply != nil {
+			it.Status, _ = protojson.MarshalOptions{UseProtoNames: true}.Marshal(res.Reply)
+		}
+		items = append(items, it)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if len(items) == 1 {
+		_ = enc.Encode(items[0])
+		return
+	}
+	_ = enc.Encode(items)
+}
+
+func printTables(w io.Writer, results []*result) {
+	for i, res := range results {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		printSummary(w, res)
+		if res.Reply == nil {
+			continue
+		}
+		if len(res.Reply.GetChecks()) > 0 {
+			printChecks(w, res.Reply.GetChecks())
+		}
+		if len(res.Reply.GetPhases()) > 0 {
+			printPhases(w, res.Reply.GetPhases())
+		}
+	}
+}
+
+func printSummary(w io.Writer, res *result) {
+	tw := table.NewWriter()
+	tw.SetOutputMirror(w)
+	tw.AppendRow(table.Row{"实例", res.Endpoint})
+	tw.AppendRow(table.Row{"检查", flagProbe})
+	tw.AppendRow(table.Row{"状态", statusText(res.Overall)})
+	if res.Err != nil {
+		tw.AppendRow(table.Row{"错误", errors.FromError(res.Err).GetMessage()})
+	}
+	if r := res.Reply; r != nil {
+		tw.AppendRow(table.Row{"服务", r.GetService()})
+		tw.AppendRow(table.Row{"pc_id", r.GetPcId()})
+		tw.AppendRow(table.Row{"版本", r.GetVersionInfo().GetVersion()})
+		tw.AppendRow(table.Row{"运行时长", (time.Duration(r.GetUptimeSeconds()) * time.Second).String()})
+		for _, k := range sortedKeys(r.GetRuntime()) {
+			tw.AppendRow(table.Row{k, r.GetRuntime()[k]})
+		}
+	}
+	tw.Render()
+}
+
+func printChecks(w io.Writer, checks []*healthv1.Check) {
+	tw := table.NewWriter()
+	tw.SetOutputMirror(w)
+	tw.AppendHeader(table.Row{"序号", "检查项", "状态", "关键", "耗时(ms)", "状态起始", "原因", "详细信息"})
+	for i, c := range checks {
+		since := ""
+		if c.GetSince() != nil {
+			since = c.GetSince().AsTime().Local().Format(time.DateTime)
+		}
+		md := c.GetMetadata()
+		var details []string
+		for _, k := range sortedKeys(md) {
+			if k == "critical" {
+				continue
+			}
+			details = append(details, k+"="+md[k])
+		}
+		tw.AppendRow(table.Row{i + 1, c.GetName(), statusText(c.GetStatus()), md["critical"], c.GetLatencyMs(), since, c.GetReason(), strings.Join(details, "\n")})
+	}
+	tw.Render()
+}
+
+func printPhases(w io.Writer, phases []*healthv1.Phase) {
+	tw := table.NewWriter()
+	tw.SetOutputMirror(w)
+	tw.AppendHeader(table.Row{"序号", "启动阶段", "状态", "耗时(ms)", "错误"})
+	for i, p := range phases {
+		tw.AppendRow(table.Row{i + 1, p.GetName(), strings.TrimPrefix(p.GetState().String(), "PHASE_STATE_"), p.GetDurationMs(), p.GetError()})
+	}
+	tw.Render()
+}
+
+func statusText(s healthv1.Status) string {
+	return strings.TrimPrefix(s.String(), "STATUS_")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
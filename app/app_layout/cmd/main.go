@@ -4,6 +4,7 @@ import (
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/spf13/cobra"
 
+	"github.com/jeffinity/app-layout/app/app_layout/cmd/health"
 	"github.com/jeffinity/app-layout/app/app_layout/cmd/migrate"
 	"github.com/jeffinity/app-layout/app/app_layout/cmd/server"
 )
@@ -22,12 +23,14 @@ func init() {
 	rootCmd.AddCommand(server.Command())
 	rootCmd.AddCommand(CmdVersion())
 	rootCmd.AddCommand(migrate.CmdMigrate())
+	rootCmd.AddCommand(health.CmdHealth())
 }
 
 func main() {
 
 	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
-		if cmd.Name() != "version" {
+		// health 的输出可能被脚本解析（-o json），不打印启动信息
+		if cmd.Name() != "version" && cmd.Name() != "health" {
 			ShowInfo()
 		}
 	}
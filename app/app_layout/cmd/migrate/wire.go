@@ -12,11 +12,15 @@ import (
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/wire"
 
+	"github.com/jeffinity/app-layout/app/app_layout/internal/app_init"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/data"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/health"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/metricx"
 )
 
 // initMigrator init db migrator.
 func initMigrator(root context.Context, c *conf.Bootstrap, logger log.Logger) (*CmdMigrator, func(), error) {
-	panic(wire.Build(NewMigrator, data.ProviderSet))
+	// 迁移不暴露 /metrics，注册表仅用于满足 data 层依赖
+	panic(wire.Build(NewMigrator, wire.Value(app_init.AppInfo{}), data.ProviderSet, health.ProviderSet, metricx.ProviderSet))
 }
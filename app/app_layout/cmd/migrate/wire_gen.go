@@ -9,31 +9,56 @@ package migrate
 import (
 	"context"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/app_init"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/data"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/health"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/metricx"
 )
 
 // Injectors from wire.go:
 
 // initMigrator init db migrator.
 func initMigrator(root context.Context, c *conf.Bootstrap, logger log.Logger) (*CmdMigrator, func(), error) {
-	db, err := data.NewPostgres(c, logger)
+	replicas, cleanup, err := data.NewReplicas(c, logger)
 	if err != nil {
 		return nil, nil, err
 	}
-	clusterClient, cleanup, err := data.NewRedis(root, c, logger)
+	db, err := data.NewDB(c, replicas, logger)
 	if err != nil {
+		cleanup()
 		return nil, nil, err
 	}
-	dataData, cleanup2, err := data.NewData(c, db, clusterClient, logger)
+	universalClient, cleanup2, err := data.NewRedis(root, c, logger)
 	if err != nil {
 		cleanup()
 		return nil, nil, err
 	}
+	registry := health.NewRegistry(c)
+	appInfo := _wireAppInfoValue
+	metricxRegistry, cleanup3, err := metricx.NewRegistry(appInfo, c, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	dataData, cleanup4, err := data.NewData(c, db, replicas, universalClient, registry, metricxRegistry, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	migrator := data.NewAllMigrator(dataData)
 	cmdMigrator := NewMigrator(root, migrator)
 	return cmdMigrator, func() {
+		cleanup4()
+		cleanup3()
 		cleanup2()
 		cleanup()
 	}, nil
 }
+
+var (
+	_wireAppInfoValue = app_init.AppInfo{}
+)
@@ -14,7 +14,6 @@ import (
 	"github.com/jeffinity/singularity/buildinfo"
 	"github.com/jeffinity/singularity/friendly"
 	"github.com/jeffinity/singularity/kratosx"
-	"github.com/jeffinity/singularity/nacosx"
 	"github.com/jeffinity/singularity/pprof"
 	"github.com/oklog/ulid/v2"
 	"github.com/spf13/cobra"
@@ -22,6 +21,9 @@ import (
 
 	"github.com/jeffinity/app-layout/app/app_layout/internal/app_init"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/data"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/health"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/server"
 )
 
 var (
@@ -101,13 +103,19 @@ func runServer() {
 	mLog.Infof("Exited & Bye-bye")
 }
 
+func newAppInfo(pcID kratosx.ServiceID) app_init.AppInfo {
+	return app_init.AppInfo{Name: ServiceName, ID: pcID}
+}
+
 func newApp(
 	pcID kratosx.ServiceID,
 	logger log.Logger,
 	c *conf.Bootstrap,
 	gs *grpc.Server,
 	hs *http.Server,
-	nr *nacosx.Registry,
+	hp *health.Prober,
+	rr *server.ReadinessRegistrar,
+	relay *data.OutboxRelay,
 ) (*kratos.App, error) {
 
 	endpoints, err := kratosx.ParseEndpoints(c.GetServer().GetHttp(), c.GetServer().GetGrpc())
@@ -128,11 +136,13 @@ func newApp(
 		kratos.Server(
 			gs,
 			hs,
+			hp,
+			relay,
 		),
 	}
 
-	if nr != nil {
-		opts = append(opts, kratos.Registrar(nr))
+	if rr != nil {
+		opts = append(opts, kratos.Registrar(rr))
 	}
 	return kratos.New(opts...), nil
 }
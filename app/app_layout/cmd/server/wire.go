@@ -20,6 +20,8 @@ import (
 	"github.com/jeffinity/app-layout/app/app_layout/internal/biz"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/data"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/health"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/metricx"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/server"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/service"
 )
@@ -28,7 +30,10 @@ import (
 func initApp(pcID kratosx.ServiceID, root context.Context, c *conf.Bootstrap, wg *sync.WaitGroup, logger log.Logger) (*kratos.App, func(), error) {
 	panic(wire.Build(
 		newApp,
+		newAppInfo,
 		app_init.NewNacosConf,
+		metricx.ProviderSet,
+		health.ProviderSet,
 		data.ProviderSet,
 		server.ProviderSet,
 		biz.ProviderSet,
@@ -14,6 +14,8 @@ import (
 	"github.com/jeffinity/app-layout/app/app_layout/internal/biz"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/data"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/health"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/metricx"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/server"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/service"
 	"github.com/jeffinity/singularity/kratosx"
@@ -25,44 +27,107 @@ import (
 
 // initApp init kratos application.
 func initApp(pcID kratosx.ServiceID, root context.Context, c *conf.Bootstrap, wg *sync.WaitGroup, logger log.Logger) (*kratos.App, func(), error) {
-	db, err := data.NewPostgres(c, logger)
+	appInfo := newAppInfo(pcID)
+	registry, cleanup, err := metricx.NewRegistry(appInfo, c, logger)
 	if err != nil {
 		return nil, nil, err
 	}
-	clusterClient, cleanup, err := data.NewRedis(root, c, logger)
+	serverMetrics, err := server.NewServerMetrics(registry)
 	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	replicas, cleanup2, err := data.NewReplicas(c, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	db, err := data.NewDB(c, replicas, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	universalClient, cleanup3, err := data.NewRedis(root, c, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
 		return nil, nil, err
 	}
-	dataData, cleanup2, err := data.NewData(c, db, clusterClient, logger)
+	healthRegistry := health.NewRegistry(c)
+	dataData, cleanup4, err := data.NewData(c, db, replicas, universalClient, healthRegistry, registry, logger)
 	if err != nil {
+		cleanup3()
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	helloRepo := data.NewHelloRepo(dataData, logger)
-	helloUseCase := biz.NewHelloUseCase(helloRepo, logger)
-	healthService := service.NewHealthService(logger, c, helloUseCase)
-	grpcServer := server.NewGRPCServer(c, healthService, logger)
-	httpServer := server.NewHTTPServer(c, healthService, logger)
+	idempotencyRepo := data.NewIdempotencyRepo(dataData, logger)
+	idempotency := server.NewIdempotency(c, idempotencyRepo, logger)
+	startup := health.NewStartup()
+	prober, err := health.NewProber(c, healthRegistry, startup, registry, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	healthService := service.NewHealthService(logger, c, appInfo, prober, startup)
+	cacheMetrics, err := data.NewCacheMetrics(registry)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	helloRepo := data.NewHelloRepo(dataData, cacheMetrics, logger)
+	transaction := data.NewTransaction(dataData)
+	outbox := data.NewOutbox(dataData)
+	helloUseCase := biz.NewHelloUseCase(helloRepo, transaction, outbox, logger)
+	helloService := service.NewHelloService(logger, helloUseCase)
+	grpcServer := server.NewGRPCServer(c, serverMetrics, idempotency, healthService, helloService, logger)
+	httpServer := server.NewHTTPServer(c, appInfo, registry, serverMetrics, idempotency, healthService, helloService, logger)
 	nacosxConf := app_init.NewNacosConf(c)
 	iNamingClient, err := nacosx.NewNamingClient(nacosxConf)
 	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	nacosxRegistry, err := nacosx.NewRegistryEngine(nacosxConf, iNamingClient)
+	if err != nil {
+		cleanup4()
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	registry, err := nacosx.NewRegistryEngine(nacosxConf, iNamingClient)
+	readinessRegistrar := server.NewReadinessRegistrar(c, nacosxRegistry, startup, prober, logger)
+	eventSink := data.NewRedisStreamSink(c, dataData)
+	locker := data.NewLocker(dataData, logger)
+	outboxRelay, err := data.NewOutboxRelay(c, dataData, eventSink, locker, registry, logger)
 	if err != nil {
+		cleanup4()
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	app, err := newApp(pcID, logger, c, grpcServer, httpServer, registry)
+	app, err := newApp(pcID, logger, c, grpcServer, httpServer, prober, readinessRegistrar, outboxRelay)
 	if err != nil {
+		cleanup4()
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	return app, func() {
+		cleanup4()
+		cleanup3()
 		cleanup2()
 		cleanup()
 	}, nil
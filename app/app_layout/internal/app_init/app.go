@@ -0,0 +1,9 @@
+package app_init
+
+import "github.com/jeffinity/singularity/kratosx"
+
+// AppInfo 当前进程的应用标识，由各 cmd 入口构造后经 wire 注入
+type AppInfo struct {
+	Name kratosx.ServiceName
+	ID   kratosx.ServiceID
+}
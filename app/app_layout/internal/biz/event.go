@@ -0,0 +1,31 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// Event 领域事件
+type Event struct {
+	AggregateType string // 聚合类型，如 "hello"，决定投递的 stream
+	AggregateID   string // 聚合 ID，同一聚合的事件按写入顺序投递
+	Type          string // 事件类型，如 "hello.created"
+	Payload       []byte // 事件内容，通常为 JSON
+}
+
+// NewEvent 以 JSON 编码 payload 构造事件
+func NewEvent(aggregateType, aggregateID, eventType string, payload any) (*Event, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "encode %s event payload:", eventType)
+	}
+	return &Event{AggregateType: aggregateType, AggregateID: aggregateID, Type: eventType, Payload: b}, nil
+}
+
+// Outbox 事务性发件箱，由 data 层实现：
+// 在 Transaction.InTx 中与业务写入一同调用 Append，事件随事务提交或回滚，提交后由 relay 异步投递（至少一次）
+type Outbox interface {
+	Append(ctx context.Context, events ...*Event) error
+}
@@ -2,24 +2,148 @@ package biz
 
 import (
 	"context"
+	"strconv"
+	"time"
 
+	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
 )
 
+const (
+	defaultHelloPageSize = 20
+	maxHelloPageSize     = 100
+)
+
+var ErrHelloNotFound = errors.NotFound("HELLO_NOT_FOUND", "hello not found")
+
+// Hello 领域对象
+type Hello struct {
+	ID        int64
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// HelloFilter 列表查询条件
+type HelloFilter struct {
+	NamePrefix string // 为空不过滤
+	Offset     int
+	Limit      int
+}
+
 type HelloRepo interface {
-	Save(context.Context) error
-	Update(context.Context) error
-	ListAll(context.Context) error
+	// Save 创建并返回带 ID、时间戳的对象
+	Save(ctx context.Context, h *Hello) (*Hello, error)
+	// Update 按 ID 更新名称，不存在时返回 ErrHelloNotFound
+	Update(ctx context.Context, h *Hello) (*Hello, error)
+	// FindByID 不存在时返回 ErrHelloNotFound
+	FindByID(ctx context.Context, id int64) (*Hello, error)
+	// Delete 不存在时返回 ErrHelloNotFound
+	Delete(ctx context.Context, id int64) error
+	// List 按 ID 倒序分页，同时返回满足条件的总数
+	List(ctx context.Context, f HelloFilter) ([]*Hello, int64, error)
+}
+
+// Hello 领域事件，经 Outbox 投递
+const (
+	helloAggregate    = "hello"
+	HelloEventCreated = "hello.created"
+	HelloEventUpdated = "hello.updated"
+	HelloEventDeleted = "hello.deleted"
+)
+
+// HelloEventPayload Hello 事件内容，deleted 事件仅包含 ID
+type HelloEventPayload struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name,omitempty"`
 }
 
 type HelloUseCase struct {
-	repo HelloRepo
-	log  *log.Helper
+	repo   HelloRepo
+	tx     Transaction
+	outbox Outbox
+	log    *log.Helper
 }
 
-func NewHelloUseCase(hr HelloRepo, logger log.Logger) *HelloUseCase {
+func NewHelloUseCase(hr HelloRepo, tx Transaction, outbox Outbox, logger log.Logger) *HelloUseCase {
 	return &HelloUseCase{
-		repo: hr,
-		log:  log.NewHelper(log.With(logger, "module", "app_layout/HelloUseCase")),
+		repo:   hr,
+		tx:     tx,
+		outbox: outbox,
+		log:    log.NewHelper(log.With(logger, "module", "app_layout/HelloUseCase")),
+	}
+}
+
+// appendEvent 在当前事务中写入 Hello 事件
+func (uc *HelloUseCase) appendEvent(ctx context.Context, eventType string, payload HelloEventPayload) error {
+	e, err := NewEvent(helloAggregate, strconv.FormatInt(payload.ID, 10), eventType, payload)
+	if err != nil {
+		return err
+	}
+	return uc.outbox.Append(ctx, e)
+}
+
+func (uc *HelloUseCase) Create(ctx context.Context, name string) (*Hello, error) {
+	var h *Hello
+	err := uc.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		if h, err = uc.repo.Save(ctx, &Hello{Name: name}); err != nil {
+			return err
+		}
+		return uc.appendEvent(ctx, HelloEventCreated, HelloEventPayload{ID: h.ID, Name: h.Name})
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.log.WithContext(ctx).Infof("hello created, id: %d, name: %s", h.ID, h.Name)
+	return h, nil
+}
+
+func (uc *HelloUseCase) Get(ctx context.Context, id int64) (*Hello, error) {
+	return uc.repo.FindByID(ctx, id)
+}
+
+func (uc *HelloUseCase) Update(ctx context.Context, id int64, name string) (*Hello, error) {
+	var h *Hello
+	err := uc.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		if h, err = uc.repo.Update(ctx, &Hello{ID: id, Name: name}); err != nil {
+			return err
+		}
+		return uc.appendEvent(ctx, HelloEventUpdated, HelloEventPayload{ID: h.ID, Name: h.Name})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (uc *HelloUseCase) Delete(ctx context.Context, id int64) error {
+	err := uc.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return uc.appendEvent(ctx, HelloEventDeleted, HelloEventPayload{ID: id})
+	})
+	if err != nil {
+		return err
+	}
+	uc.log.WithContext(ctx).Infof("hello deleted, id: %d", id)
+	return nil
+}
+
+// List page 从 1 开始；page <= 0 视为 1，pageSize <= 0 取默认值，超过上限时截断
+func (uc *HelloUseCase) List(ctx context.Context, page, pageSize int, namePrefix string) ([]*Hello, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultHelloPageSize
 	}
+	pageSize = min(pageSize, maxHelloPageSize)
+	return uc.repo.List(ctx, HelloFilter{
+		NamePrefix: namePrefix,
+		Offset:     (page - 1) * pageSize,
+		Limit:      pageSize,
+	})
 }
@@ -0,0 +1,147 @@
+package biz_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"testing"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/oklog/ulid/v2"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/biz"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/data"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/test"
+)
+
+// namePrefix 返回本测试独有的名称前缀，共用测试库时各测试的数据互不干扰
+func namePrefix() string {
+	return ulid.Make().String() + "-"
+}
+
+// outboxEvents 按写入顺序返回 outbox 表中 hello id 对应的事件
+func outboxEvents(t *testing.T, r *test.Resource, id int64) []*data.OutboxEvent {
+	t.Helper()
+	db, err := r.Data().DB(r.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var events []*data.OutboxEvent
+	if err := db.Where("aggregate_id = ?", strconv.FormatInt(id, 10)).Order("id").Find(&events).Error; err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+func TestHelloUseCaseCRUD(t *testing.T) {
+	r := test.InitTest()
+	uc, ctx := r.HelloUseCase(), r.Context()
+	alice, bob := namePrefix()+"alice", namePrefix()+"bob"
+
+	created, err := uc.Create(ctx, alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := uc.Get(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != alice {
+		t.Fatalf("got name %q, want %q", got.Name, alice)
+	}
+	if _, err := uc.Update(ctx, created.ID, bob); err != nil {
+		t.Fatal(err)
+	}
+	if err := uc.Delete(ctx, created.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := uc.Get(ctx, created.ID); !errors.Is(err, biz.ErrHelloNotFound) || kerrors.Code(err) != 404 {
+		t.Fatalf("got %v, want 404 HELLO_NOT_FOUND", err)
+	}
+
+	// 每次写操作在同一事务中写入一条事件
+	events := outboxEvents(t, r, created.ID)
+	wantTypes := []string{biz.HelloEventCreated, biz.HelloEventUpdated, biz.HelloEventDeleted}
+	wantNames := []string{alice, bob, ""}
+	if len(events) != len(wantTypes) {
+		t.Fatalf("got %d events, want %d", len(events), len(wantTypes))
+	}
+	for i, e := range events {
+		var payload biz.HelloEventPayload
+		if err := json.Unmarshal(e.Payload, &payload); err != nil {
+			t.Fatal(err)
+		}
+		if e.EventType != wantTypes[i] || payload.ID != created.ID || payload.Name != wantNames[i] {
+			t.Fatalf("events[%d] = %s %s %s, want %s", i, e.EventType, e.AggregateID, e.Payload, wantTypes[i])
+		}
+	}
+}
+
+func TestHelloUseCaseNotFound(t *testing.T) {
+	r := test.InitTest()
+	uc, ctx := r.HelloUseCase(), r.Context()
+	const id = math.MaxInt64
+
+	for name, err := range map[string]error{
+		"get":    func() error { _, err := uc.Get(ctx, id); return err }(),
+		"update": func() error { _, err := uc.Update(ctx, id, "bob"); return err }(),
+		"delete": uc.Delete(ctx, id),
+	} {
+		if !errors.Is(err, biz.ErrHelloNotFound) || kerrors.Code(err) != 404 {
+			t.Fatalf("%s: got %v, want 404 HELLO_NOT_FOUND", name, err)
+		}
+	}
+	// 失败的写操作随事务回滚，不产生事件
+	if events := outboxEvents(t, r, id); len(events) != 0 {
+		t.Fatalf("got %d events, want 0", len(events))
+	}
+}
+
+func TestHelloUseCaseListPaging(t *testing.T) {
+	r := test.InitTest()
+	uc, ctx := r.HelloUseCase(), r.Context()
+	p := namePrefix()
+
+	const n = 105
+	ids := make([]int64, 0, n)
+	for i := range n {
+		h, err := uc.Create(ctx, p+strconv.Itoa(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, h.ID)
+	}
+	slices.Reverse(ids)
+
+	tests := []struct {
+		name           string
+		page, pageSize int
+		want           []int64
+	}{
+		{name: "defaults", page: 0, pageSize: 0, want: ids[:20]},
+		{name: "negative page", page: -1, pageSize: 10, want: ids[:10]},
+		{name: "page size capped", page: 1, pageSize: 1000, want: ids[:100]},
+		{name: "last page", page: 2, pageSize: 100, want: ids[100:]},
+		{name: "beyond last page", page: 3, pageSize: 100, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hellos, total, err := uc.List(ctx, tt.page, tt.pageSize, p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if total != n {
+				t.Fatalf("got total %d, want %d", total, n)
+			}
+			got := make([]int64, 0, len(hellos))
+			for _, h := range hellos {
+				got = append(got, h.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("got ids %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package biz
+
+import (
+	"context"
+	"time"
+)
+
+// IdempotencyRecord 幂等键对应的首次成功响应
+type IdempotencyRecord struct {
+	Fingerprint string // 请求指纹，同一幂等键被用于不同请求时据此拒绝
+	Reply       []byte // anypb 序列化后的响应
+}
+
+// IdempotencyState 占用幂等键的结果
+type IdempotencyState int
+
+const (
+	IdempotencyAcquired  IdempotencyState = iota // 首次请求，已占用，需执行后 Complete / Release
+	IdempotencyInFlight                          // 相同幂等键的请求正在处理中
+	IdempotencyCompleted                         // 已有首次响应，可直接回放
+)
+
+type IdempotencyRepo interface {
+	// Acquire 以 token 占用幂等键，lockTTL 内未 Complete / Release 则自动释放；
+	// 状态为 IdempotencyCompleted 时返回已保存的记录
+	Acquire(ctx context.Context, key, token string, lockTTL time.Duration) (IdempotencyState, *IdempotencyRecord, error)
+	// Complete 保存首次响应，仅当幂等键仍被 token 占用时生效
+	Complete(ctx context.Context, key, token string, rec *IdempotencyRecord, ttl time.Duration) error
+	// Release 释放占用（处理失败时调用，允许客户端重试），仅当幂等键仍被 token 占用时生效
+	Release(ctx context.Context, key, token string) error
+}
@@ -0,0 +1,46 @@
+package biz
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+var (
+	// ErrLockHeld TryLock 时锁已被其他持有者占用
+	ErrLockHeld = errors.Conflict("LOCK_HELD", "lock is held by another owner")
+	// ErrLockLost 租约丢失（续约失败直至过期，或锁被其他持有者获取），作为 Lock.Context 的取消原因
+	ErrLockLost = errors.Conflict("LOCK_LOST", "lock lease lost")
+)
+
+// Locker 分布式锁，由 data 层基于 Redis 实现，用于跨副本只能执行一次的任务（如每日重算、独占资源处理）
+type Locker interface {
+	// TryLock 尝试获取 key 对应的锁，租约为 ttl，已被占用时立即返回 ErrLockHeld
+	TryLock(ctx context.Context, key string, ttl time.Duration) (Lock, error)
+	// Lock 获取 key 对应的锁，已被占用时等待直到获取成功或 ctx 结束
+	Lock(ctx context.Context, key string, ttl time.Duration) (Lock, error)
+}
+
+// Lock 已获取的锁：持有期间自动续约，续约失败直至租约过期时取消 Context
+type Lock interface {
+	// Context 派生自获取锁时的 ctx，Unlock 或租约丢失时取消，租约丢失时 context.Cause 为 ErrLockLost；
+	// 持有锁执行的操作应使用该 ctx，以便租约丢失后及时停止
+	Context() context.Context
+	// Token fencing token，同一 key 每次成功获取严格递增；
+	// 写外部资源时携带并由资源端拒绝小于已见值的写入，防止租约丢失后的旧持有者覆盖新持有者
+	Token() int64
+	// Unlock 停止续约并释放锁，仅在锁仍由自己持有时删除，重复调用无副作用
+	Unlock(ctx context.Context) error
+}
+
+// WithLock 获取锁后以 Lock.Context 执行 fn，结束后释放锁；锁被占用时返回 ErrLockHeld
+func WithLock(ctx context.Context, locker Locker, key string, ttl time.Duration, fn func(ctx context.Context, token int64) error) error {
+	l, err := locker.TryLock(ctx, key, ttl)
+	if err != nil {
+		return err
+	}
+	// 释放失败时锁在租约到期后自动释放
+	defer func() { _ = l.Unlock(context.WithoutCancel(ctx)) }()
+	return fn(l.Context(), l.Token())
+}
@@ -0,0 +1,24 @@
+package biz
+
+import "context"
+
+// Transaction 事务管理，由 data 层实现：
+// fn 内使用传入的 ctx 调用的 repo 方法在同一事务中执行，fn 返回错误或 panic 时回滚；
+// 嵌套调用 InTx 时以 savepoint 实现，内层失败仅回滚到 savepoint，外层可继续执行或返回错误整体回滚
+type Transaction interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type readPrimaryKey struct{}
+
+// WithReadPrimary 标记 ctx 中的查询走主库，用于写后立即读（read-your-writes）等不能容忍副本延迟的场景；
+// 未配置只读副本或处于 InTx 事务中时查询本就走主库，该标记不产生影响
+func WithReadPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readPrimaryKey{}, true)
+}
+
+// ReadPrimary ctx 是否经 WithReadPrimary 标记
+func ReadPrimary(ctx context.Context) bool {
+	v, _ := ctx.Value(readPrimaryKey{}).(bool)
+	return v
+}
@@ -27,6 +27,8 @@ type Bootstrap struct {
 	Server        *Servers               `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
 	Log           *Log                   `protobuf:"bytes,2,opt,name=log,proto3" json:"log,omitempty"`
 	Data          *Data                  `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Metrics       *Metrics               `protobuf:"bytes,4,opt,name=metrics,proto3" json:"metrics,omitempty"`
+	Health        *Health                `protobuf:"bytes,5,opt,name=health,proto3" json:"health,omitempty"`
 	Nacos         *Nacos                 `protobuf:"bytes,101,opt,name=nacos,proto3" json:"nacos,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -83,6 +85,20 @@ func (x *Bootstrap) GetData() *Data {
 	return nil
 }
 
+func (x *Bootstrap) GetMetrics() *Metrics {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetHealth() *Health {
+	if x != nil {
+		return x.Health
+	}
+	return nil
+}
+
 func (x *Bootstrap) GetNacos() *Nacos {
 	if x != nil {
 		return x.Nacos
@@ -154,6 +170,158 @@ func (x *RedisCluster) GetReadOnly() bool {
 	return false
 }
 
+// Redis Sentinel 模式，连接由 sentinel 选出的 master
+type RedisSentinel struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// master 名称，与 sentinel.conf 中 sentinel monitor <name> 一致
+	MasterName string `protobuf:"bytes,1,opt,name=master_name,json=masterName,proto3" json:"master_name,omitempty"`
+	// sentinel 节点列表，例：["172.31.32.200:26379","172.31.32.201:26379"]
+	Addrs []string `protobuf:"bytes,2,rep,name=addrs,proto3" json:"addrs,omitempty"`
+	// Redis 节点的 requirepass
+	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	// sentinel 节点的 requirepass，未设置时不认证
+	SentinelPassword string `protobuf:"bytes,4,opt,name=sentinel_password,json=sentinelPassword,proto3" json:"sentinel_password,omitempty"`
+	Db               int32  `protobuf:"varint,5,opt,name=db,proto3" json:"db,omitempty"`
+	// 只读命令路由到副本
+	ReplicaRead   bool `protobuf:"varint,6,opt,name=replica_read,json=replicaRead,proto3" json:"replica_read,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedisSentinel) Reset() {
+	*x = RedisSentinel{}
+	mi := &file_conf_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedisSentinel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedisSentinel) ProtoMessage() {}
+
+func (x *RedisSentinel) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedisSentinel.ProtoReflect.Descriptor instead.
+func (*RedisSentinel) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RedisSentinel) GetMasterName() string {
+	if x != nil {
+		return x.MasterName
+	}
+	return ""
+}
+
+func (x *RedisSentinel) GetAddrs() []string {
+	if x != nil {
+		return x.Addrs
+	}
+	return nil
+}
+
+func (x *RedisSentinel) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *RedisSentinel) GetSentinelPassword() string {
+	if x != nil {
+		return x.SentinelPassword
+	}
+	return ""
+}
+
+func (x *RedisSentinel) GetDb() int32 {
+	if x != nil {
+		return x.Db
+	}
+	return 0
+}
+
+func (x *RedisSentinel) GetReplicaRead() bool {
+	if x != nil {
+		return x.ReplicaRead
+	}
+	return false
+}
+
+// Redis 连接配置，三种模式按 dsn > sentinel > cluster 的优先级选用第一个已配置的
+type Redis struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 单机模式：redis://[[user]:password@]host:port/db，TLS 使用 rediss://，也支持 unix:///path/to/redis.sock
+	Dsn           string         `protobuf:"bytes,1,opt,name=dsn,proto3" json:"dsn,omitempty"`
+	Sentinel      *RedisSentinel `protobuf:"bytes,2,opt,name=sentinel,proto3" json:"sentinel,omitempty"`
+	Cluster       *RedisCluster  `protobuf:"bytes,3,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Redis) Reset() {
+	*x = Redis{}
+	mi := &file_conf_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Redis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Redis) ProtoMessage() {}
+
+func (x *Redis) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Redis.ProtoReflect.Descriptor instead.
+func (*Redis) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Redis) GetDsn() string {
+	if x != nil {
+		return x.Dsn
+	}
+	return ""
+}
+
+func (x *Redis) GetSentinel() *RedisSentinel {
+	if x != nil {
+		return x.Sentinel
+	}
+	return nil
+}
+
+func (x *Redis) GetCluster() *RedisCluster {
+	if x != nil {
+		return x.Cluster
+	}
+	return nil
+}
+
 type Nacos struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Addr          string                 `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
@@ -166,14 +334,15 @@ type Nacos struct {
 	GroupId       string                 `protobuf:"bytes,8,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
 	DataId        string                 `protobuf:"bytes,9,opt,name=data_id,json=dataId,proto3" json:"data_id,omitempty"`
 	Weight        int32                  `protobuf:"varint,10,opt,name=weight,proto3" json:"weight,omitempty"`
-	DataIds       []string               `protobuf:"bytes,11,rep,name=data_ids,json=dataIds,proto3" json:"data_ids,omitempty"` // 支持多个 data_id，按顺序后面的会覆盖前面的参数，data_id 同时设置实际插入 data_ids 第一位
+	DataIds       []string               `protobuf:"bytes,11,rep,name=data_ids,json=dataIds,proto3" json:"data_ids,omitempty"`                   // 支持多个 data_id，按顺序后面的会覆盖前面的参数，data_id 同时设置实际插入 data_ids 第一位
+	ReadinessGate *ReadinessGate         `protobuf:"bytes,12,opt,name=readiness_gate,json=readinessGate,proto3" json:"readiness_gate,omitempty"` // 按 Readiness 自动注销 / 恢复注册
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Nacos) Reset() {
 	*x = Nacos{}
-	mi := &file_conf_proto_msgTypes[2]
+	mi := &file_conf_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -185,7 +354,7 @@ func (x *Nacos) String() string {
 func (*Nacos) ProtoMessage() {}
 
 func (x *Nacos) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_proto_msgTypes[2]
+	mi := &file_conf_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -198,7 +367,7 @@ func (x *Nacos) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Nacos.ProtoReflect.Descriptor instead.
 func (*Nacos) Descriptor() ([]byte, []int) {
-	return file_conf_proto_rawDescGZIP(), []int{2}
+	return file_conf_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Nacos) GetAddr() string {
@@ -278,6 +447,74 @@ func (x *Nacos) GetDataIds() []string {
 	return nil
 }
 
+func (x *Nacos) GetReadinessGate() *ReadinessGate {
+	if x != nil {
+		return x.ReadinessGate
+	}
+	return nil
+}
+
+// Readiness 持续失败时从 Nacos 注销实例，恢复后重新注册，避免依赖不可用的实例继续接收流量
+type ReadinessGate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Disabled      bool                   `protobuf:"varint,1,opt,name=disabled,proto3" json:"disabled,omitempty"`                   // 关闭后仅在启动 / 退出时注册 / 注销
+	DownAfter     *durationpb.Duration   `protobuf:"bytes,2,opt,name=down_after,json=downAfter,proto3" json:"down_after,omitempty"` // Readiness 持续失败多久后注销，默认 30s
+	UpAfter       *durationpb.Duration   `protobuf:"bytes,3,opt,name=up_after,json=upAfter,proto3" json:"up_after,omitempty"`       // 注销后 Readiness 持续成功多久重新注册，默认 10s
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadinessGate) Reset() {
+	*x = ReadinessGate{}
+	mi := &file_conf_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadinessGate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadinessGate) ProtoMessage() {}
+
+func (x *ReadinessGate) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadinessGate.ProtoReflect.Descriptor instead.
+func (*ReadinessGate) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReadinessGate) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *ReadinessGate) GetDownAfter() *durationpb.Duration {
+	if x != nil {
+		return x.DownAfter
+	}
+	return nil
+}
+
+func (x *ReadinessGate) GetUpAfter() *durationpb.Duration {
+	if x != nil {
+		return x.UpAfter
+	}
+	return nil
+}
+
 type Log struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DisableStdLog bool                   `protobuf:"varint,1,opt,name=disable_std_log,json=disableStdLog,proto3" json:"disable_std_log,omitempty"` // 禁用标准输出
@@ -295,7 +532,7 @@ type Log struct {
 
 func (x *Log) Reset() {
 	*x = Log{}
-	mi := &file_conf_proto_msgTypes[3]
+	mi := &file_conf_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -307,7 +544,7 @@ func (x *Log) String() string {
 func (*Log) ProtoMessage() {}
 
 func (x *Log) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_proto_msgTypes[3]
+	mi := &file_conf_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -320,7 +557,7 @@ func (x *Log) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Log.ProtoReflect.Descriptor instead.
 func (*Log) Descriptor() ([]byte, []int) {
-	return file_conf_proto_rawDescGZIP(), []int{3}
+	return file_conf_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Log) GetDisableStdLog() bool {
@@ -386,29 +623,30 @@ func (x *Log) GetRotateDaily() bool {
 	return false
 }
 
-type Servers struct {
+type Metrics struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Grpc          *Server                `protobuf:"bytes,1,opt,name=grpc,proto3" json:"grpc,omitempty"`
-	Http          *Server                `protobuf:"bytes,2,opt,name=http,proto3" json:"http,omitempty"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                                                                                                  // 指标名前缀（prometheus namespace），为空则不加前缀
+	ConstLabels   map[string]string      `protobuf:"bytes,2,rep,name=const_labels,json=constLabels,proto3" json:"const_labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 附加到所有指标的常量标签，service / pc_id 会自动注入
+	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`                                                                                                            // HTTP 暴露路径，默认 /metrics
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Servers) Reset() {
-	*x = Servers{}
-	mi := &file_conf_proto_msgTypes[4]
+func (x *Metrics) Reset() {
+	*x = Metrics{}
+	mi := &file_conf_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Servers) String() string {
+func (x *Metrics) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Servers) ProtoMessage() {}
+func (*Metrics) ProtoMessage() {}
 
-func (x *Servers) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_proto_msgTypes[4]
+func (x *Metrics) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -419,50 +657,59 @@ func (x *Servers) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Servers.ProtoReflect.Descriptor instead.
-func (*Servers) Descriptor() ([]byte, []int) {
-	return file_conf_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use Metrics.ProtoReflect.Descriptor instead.
+func (*Metrics) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *Servers) GetGrpc() *Server {
+func (x *Metrics) GetNamespace() string {
 	if x != nil {
-		return x.Grpc
+		return x.Namespace
 	}
-	return nil
+	return ""
 }
 
-func (x *Servers) GetHttp() *Server {
+func (x *Metrics) GetConstLabels() map[string]string {
 	if x != nil {
-		return x.Http
+		return x.ConstLabels
 	}
 	return nil
 }
 
-type Server struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Addr           string                 `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
-	Network        string                 `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
-	Timeout        *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
-	PublicEndpoint string                 `protobuf:"bytes,4,opt,name=public_endpoint,json=publicEndpoint,proto3" json:"public_endpoint,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *Metrics) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
 }
 
-func (x *Server) Reset() {
-	*x = Server{}
-	mi := &file_conf_proto_msgTypes[5]
+type Health struct {
+	state            protoimpl.MessageState  `protogen:"open.v1"`
+	Interval         *durationpb.Duration    `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`                                                                       // 后台检查间隔，默认 10s
+	Timeout          *durationpb.Duration    `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`                                                                         // 单项检查超时，默认 800ms
+	FailureThreshold int32                   `protobuf:"varint,3,opt,name=failure_threshold,json=failureThreshold,proto3" json:"failure_threshold,omitempty"`                              // 连续失败多少次判定为 DOWN，默认 3
+	SuccessThreshold int32                   `protobuf:"varint,4,opt,name=success_threshold,json=successThreshold,proto3" json:"success_threshold,omitempty"`                              // 连续成功多少次恢复为 UP，默认 1
+	HistorySize      int32                   `protobuf:"varint,5,opt,name=history_size,json=historySize,proto3" json:"history_size,omitempty"`                                             // 保留的状态变更记录数，默认 100
+	Checks           map[string]*HealthCheck `protobuf:"bytes,6,rep,name=checks,proto3" json:"checks,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 按检查项名覆盖单项配置，如 redis / postgres
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Health) Reset() {
+	*x = Health{}
+	mi := &file_conf_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Server) String() string {
+func (x *Health) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Server) ProtoMessage() {}
+func (*Health) ProtoMessage() {}
 
-func (x *Server) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_proto_msgTypes[5]
+func (x *Health) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -473,62 +720,560 @@ func (x *Server) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Server.ProtoReflect.Descriptor instead.
-func (*Server) Descriptor() ([]byte, []int) {
-	return file_conf_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use Health.ProtoReflect.Descriptor instead.
+func (*Health) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *Server) GetAddr() string {
+func (x *Health) GetInterval() *durationpb.Duration {
 	if x != nil {
-		return x.Addr
+		return x.Interval
 	}
-	return ""
+	return nil
 }
 
-func (x *Server) GetNetwork() string {
+func (x *Health) GetTimeout() *durationpb.Duration {
 	if x != nil {
-		return x.Network
+		return x.Timeout
 	}
-	return ""
+	return nil
 }
 
-func (x *Server) GetTimeout() *durationpb.Duration {
+func (x *Health) GetFailureThreshold() int32 {
 	if x != nil {
-		return x.Timeout
+		return x.FailureThreshold
 	}
-	return nil
+	return 0
 }
 
-func (x *Server) GetPublicEndpoint() string {
+func (x *Health) GetSuccessThreshold() int32 {
 	if x != nil {
-		return x.PublicEndpoint
+		return x.SuccessThreshold
 	}
-	return ""
+	return 0
 }
 
-type Data struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Postgres      *Postgres              `protobuf:"bytes,1,opt,name=postgres,proto3" json:"postgres,omitempty"`
-	RedisCluster  *RedisCluster          `protobuf:"bytes,2,opt,name=redis_cluster,json=redisCluster,proto3" json:"redis_cluster,omitempty"`
+func (x *Health) GetHistorySize() int32 {
+	if x != nil {
+		return x.HistorySize
+	}
+	return 0
+}
+
+func (x *Health) GetChecks() map[string]*HealthCheck {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+type HealthCheck struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 是否为关键依赖：关键依赖 DOWN 时 Readiness 返回 Unavailable、整体为 DOWN；
+	// 非关键依赖 DOWN 时整体为 DEGRADED，Readiness 仍返回 OK。未设置时使用检查项注册时的声明
+	Critical *bool `protobuf:"varint,1,opt,name=critical,proto3,oneof" json:"critical,omitempty"`
+	// 是否为慢检查，StatusRequest.skip_slow 时跳过。未设置时使用检查项注册时的声明
+	Slow          *bool `protobuf:"varint,2,opt,name=slow,proto3,oneof" json:"slow,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Data) Reset() {
-	*x = Data{}
-	mi := &file_conf_proto_msgTypes[6]
+func (x *HealthCheck) Reset() {
+	*x = HealthCheck{}
+	mi := &file_conf_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Data) String() string {
+func (x *HealthCheck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data) ProtoMessage() {}
+func (*HealthCheck) ProtoMessage() {}
 
-func (x *Data) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_proto_msgTypes[6]
+func (x *HealthCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheck.ProtoReflect.Descriptor instead.
+func (*HealthCheck) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *HealthCheck) GetCritical() bool {
+	if x != nil && x.Critical != nil {
+		return *x.Critical
+	}
+	return false
+}
+
+func (x *HealthCheck) GetSlow() bool {
+	if x != nil && x.Slow != nil {
+		return *x.Slow
+	}
+	return false
+}
+
+type Servers struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Grpc          *Server                `protobuf:"bytes,1,opt,name=grpc,proto3" json:"grpc,omitempty"`
+	Http          *Server                `protobuf:"bytes,2,opt,name=http,proto3" json:"http,omitempty"`
+	AccessLog     *AccessLog             `protobuf:"bytes,3,opt,name=access_log,json=accessLog,proto3" json:"access_log,omitempty"`
+	Idempotency   *Idempotency           `protobuf:"bytes,4,opt,name=idempotency,proto3" json:"idempotency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Servers) Reset() {
+	*x = Servers{}
+	mi := &file_conf_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Servers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Servers) ProtoMessage() {}
+
+func (x *Servers) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Servers.ProtoReflect.Descriptor instead.
+func (*Servers) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Servers) GetGrpc() *Server {
+	if x != nil {
+		return x.Grpc
+	}
+	return nil
+}
+
+func (x *Servers) GetHttp() *Server {
+	if x != nil {
+		return x.Http
+	}
+	return nil
+}
+
+func (x *Servers) GetAccessLog() *AccessLog {
+	if x != nil {
+		return x.AccessLog
+	}
+	return nil
+}
+
+func (x *Servers) GetIdempotency() *Idempotency {
+	if x != nil {
+		return x.Idempotency
+	}
+	return nil
+}
+
+// 写操作幂等：对配置的 operation，按请求头 / gRPC metadata 中的 Idempotency-Key 缓存首次成功响应
+type Idempotency struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 启用幂等的 operation，例：/gainetics.probe_executor.hello.v1.HelloService/CreateHello
+	Operations []string `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	// 首次成功响应的保留时长，默认 24h
+	Ttl *durationpb.Duration `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// 处理中标记的有效期，应大于接口最长处理时间，默认 60s
+	LockTtl *durationpb.Duration `protobuf:"bytes,3,opt,name=lock_ttl,json=lockTtl,proto3" json:"lock_ttl,omitempty"`
+	// 为 true 时未携带 Idempotency-Key 的请求直接拒绝，默认放行
+	RequireKey    bool `protobuf:"varint,4,opt,name=require_key,json=requireKey,proto3" json:"require_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Idempotency) Reset() {
+	*x = Idempotency{}
+	mi := &file_conf_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Idempotency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Idempotency) ProtoMessage() {}
+
+func (x *Idempotency) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Idempotency.ProtoReflect.Descriptor instead.
+func (*Idempotency) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Idempotency) GetOperations() []string {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *Idempotency) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *Idempotency) GetLockTtl() *durationpb.Duration {
+	if x != nil {
+		return x.LockTtl
+	}
+	return nil
+}
+
+func (x *Idempotency) GetRequireKey() bool {
+	if x != nil {
+		return x.RequireKey
+	}
+	return false
+}
+
+type AccessLog struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 不记录访问日志与请求指标的 operation，例：/gainetics.probe_executor.health.v1.HealthService/Liveness
+	ExcludeOperations []string `protobuf:"bytes,1,rep,name=exclude_operations,json=excludeOperations,proto3" json:"exclude_operations,omitempty"`
+	// 不记录访问日志与请求指标的 HTTP 路径，以 * 结尾表示前缀匹配，例：/apis/health/v1/*；
+	// 仅作用于经过中间件的 proto 路由，/metrics、/openapi.json 等直接挂载的 handler 本就不记录
+	ExcludePaths []string `protobuf:"bytes,2,rep,name=exclude_paths,json=excludePaths,proto3" json:"exclude_paths,omitempty"`
+	// 成功请求的日志采样率 (0, 1]，0 表示全量记录；失败请求始终记录
+	SuccessSampleRate float64 `protobuf:"fixed64,3,opt,name=success_sample_rate,json=successSampleRate,proto3" json:"success_sample_rate,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *AccessLog) Reset() {
+	*x = AccessLog{}
+	mi := &file_conf_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessLog) ProtoMessage() {}
+
+func (x *AccessLog) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessLog.ProtoReflect.Descriptor instead.
+func (*AccessLog) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AccessLog) GetExcludeOperations() []string {
+	if x != nil {
+		return x.ExcludeOperations
+	}
+	return nil
+}
+
+func (x *AccessLog) GetExcludePaths() []string {
+	if x != nil {
+		return x.ExcludePaths
+	}
+	return nil
+}
+
+func (x *AccessLog) GetSuccessSampleRate() float64 {
+	if x != nil {
+		return x.SuccessSampleRate
+	}
+	return 0
+}
+
+type Server struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Addr           string                 `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Network        string                 `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+	Timeout        *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	PublicEndpoint string                 `protobuf:"bytes,4,opt,name=public_endpoint,json=publicEndpoint,proto3" json:"public_endpoint,omitempty"`
+	Openapi        *OpenAPI               `protobuf:"bytes,5,opt,name=openapi,proto3" json:"openapi,omitempty"` // 仅 HTTP 生效
+	Json           *JSON                  `protobuf:"bytes,6,opt,name=json,proto3" json:"json,omitempty"`       // 仅 HTTP 生效
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Server) Reset() {
+	*x = Server{}
+	mi := &file_conf_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server) ProtoMessage() {}
+
+func (x *Server) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server.ProtoReflect.Descriptor instead.
+func (*Server) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Server) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Server) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *Server) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Server) GetPublicEndpoint() string {
+	if x != nil {
+		return x.PublicEndpoint
+	}
+	return ""
+}
+
+func (x *Server) GetOpenapi() *OpenAPI {
+	if x != nil {
+		return x.Openapi
+	}
+	return nil
+}
+
+func (x *Server) GetJson() *JSON {
+	if x != nil {
+		return x.Json
+	}
+	return nil
+}
+
+// HTTP JSON 编解码选项，作用于请求体解码、响应编码（含错误响应）与流式推送；未设置时保持 kratos 默认行为
+type JSON struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UseProtoNames   *bool                  `protobuf:"varint,1,opt,name=use_proto_names,json=useProtoNames,proto3,oneof" json:"use_proto_names,omitempty"`     // 使用 proto 字段名（snake_case），默认 false 即 lowerCamelCase
+	EmitUnpopulated *bool                  `protobuf:"varint,2,opt,name=emit_unpopulated,json=emitUnpopulated,proto3,oneof" json:"emit_unpopulated,omitempty"` // 输出零值字段，默认 true
+	EnumAsNumber    *bool                  `protobuf:"varint,3,opt,name=enum_as_number,json=enumAsNumber,proto3,oneof" json:"enum_as_number,omitempty"`        // 枚举输出为数字，默认 false 即枚举名
+	Int64AsString   *bool                  `protobuf:"varint,4,opt,name=int64_as_string,json=int64AsString,proto3,oneof" json:"int64_as_string,omitempty"`     // 64 位整数输出为字符串，默认 true（避免 JS 精度丢失）
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *JSON) Reset() {
+	*x = JSON{}
+	mi := &file_conf_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JSON) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JSON) ProtoMessage() {}
+
+func (x *JSON) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JSON.ProtoReflect.Descriptor instead.
+func (*JSON) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *JSON) GetUseProtoNames() bool {
+	if x != nil && x.UseProtoNames != nil {
+		return *x.UseProtoNames
+	}
+	return false
+}
+
+func (x *JSON) GetEmitUnpopulated() bool {
+	if x != nil && x.EmitUnpopulated != nil {
+		return *x.EmitUnpopulated
+	}
+	return false
+}
+
+func (x *JSON) GetEnumAsNumber() bool {
+	if x != nil && x.EnumAsNumber != nil {
+		return *x.EnumAsNumber
+	}
+	return false
+}
+
+func (x *JSON) GetInt64AsString() bool {
+	if x != nil && x.Int64AsString != nil {
+		return *x.Int64AsString
+	}
+	return false
+}
+
+type OpenAPI struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`                         // OpenAPI v3 文档路径，默认 /openapi.json
+	DocsPath      string                 `protobuf:"bytes,3,opt,name=docs_path,json=docsPath,proto3" json:"docs_path,omitempty"` // 文档页面路径，默认 /docs
+	Title         string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`                       // 文档标题，默认使用服务名
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenAPI) Reset() {
+	*x = OpenAPI{}
+	mi := &file_conf_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenAPI) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenAPI) ProtoMessage() {}
+
+func (x *OpenAPI) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenAPI.ProtoReflect.Descriptor instead.
+func (*OpenAPI) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *OpenAPI) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *OpenAPI) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *OpenAPI) GetDocsPath() string {
+	if x != nil {
+		return x.DocsPath
+	}
+	return ""
+}
+
+func (x *OpenAPI) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type Data struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Postgres      *Postgres              `protobuf:"bytes,1,opt,name=postgres,proto3" json:"postgres,omitempty"`
+	RedisCluster  *RedisCluster          `protobuf:"bytes,2,opt,name=redis_cluster,json=redisCluster,proto3" json:"redis_cluster,omitempty"` // 已废弃，等价于 redis.cluster，仅在未配置 redis 时生效
+	Redis         *Redis                 `protobuf:"bytes,3,opt,name=redis,proto3" json:"redis,omitempty"`
+	Sqlite        *Sqlite                `protobuf:"bytes,4,opt,name=sqlite,proto3" json:"sqlite,omitempty"` // 内嵌 SQLite（纯 Go 实现），用于本地开发与单测，与 postgres 互斥
+	Outbox        *Outbox                `protobuf:"bytes,5,opt,name=outbox,proto3" json:"outbox,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data) Reset() {
+	*x = Data{}
+	mi := &file_conf_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data) ProtoMessage() {}
+
+func (x *Data) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -541,7 +1286,7 @@ func (x *Data) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Data.ProtoReflect.Descriptor instead.
 func (*Data) Descriptor() ([]byte, []int) {
-	return file_conf_proto_rawDescGZIP(), []int{6}
+	return file_conf_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *Data) GetPostgres() *Postgres {
@@ -558,16 +1303,203 @@ func (x *Data) GetRedisCluster() *RedisCluster {
 	return nil
 }
 
-type Postgres struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Dsn           string                 `protobuf:"bytes,1,opt,name=dsn,proto3" json:"dsn,omitempty"`
+func (x *Data) GetRedis() *Redis {
+	if x != nil {
+		return x.Redis
+	}
+	return nil
+}
+
+func (x *Data) GetSqlite() *Sqlite {
+	if x != nil {
+		return x.Sqlite
+	}
+	return nil
+}
+
+func (x *Data) GetOutbox() *Outbox {
+	if x != nil {
+		return x.Outbox
+	}
+	return nil
+}
+
+// Outbox 事务性发件箱：事件随业务写入同一事务落库，由 relay 异步投递到 Redis Streams；
+// 多副本时通过分布式锁保证同一时刻只有一个 relay 投递
+type Outbox struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Disabled        bool                   `protobuf:"varint,1,opt,name=disabled,proto3" json:"disabled,omitempty"`                                       // 禁用 relay（仍可写入 outbox 表）
+	PollInterval    *durationpb.Duration   `protobuf:"bytes,2,opt,name=poll_interval,json=pollInterval,proto3" json:"poll_interval,omitempty"`            // 无新事件通知时的轮询间隔，默认 1s
+	BatchSize       int32                  `protobuf:"varint,3,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`                    // 每轮读取的待投递事件数，默认 100
+	RetryBackoff    *durationpb.Duration   `protobuf:"bytes,4,opt,name=retry_backoff,json=retryBackoff,proto3" json:"retry_backoff,omitempty"`            // 投递失败后的首次重试间隔，之后指数增长，默认 1s
+	MaxRetryBackoff *durationpb.Duration   `protobuf:"bytes,5,opt,name=max_retry_backoff,json=maxRetryBackoff,proto3" json:"max_retry_backoff,omitempty"` // 重试间隔上限，默认 60s
+	Retention       *durationpb.Duration   `protobuf:"bytes,6,opt,name=retention,proto3" json:"retention,omitempty"`                                      // 已投递事件的保留时长，默认 604800s（7d）
+	StreamPrefix    string                 `protobuf:"bytes,7,opt,name=stream_prefix,json=streamPrefix,proto3" json:"stream_prefix,omitempty"`            // stream key 前缀，按聚合类型分 stream，默认 "app_layout:events:"
+	StreamMaxLen    int64                  `protobuf:"varint,8,opt,name=stream_max_len,json=streamMaxLen,proto3" json:"stream_max_len,omitempty"`         // 每个 stream 的近似最大长度，默认 100000
+	MaxAttempts     int32                  `protobuf:"varint,9,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`              // 投递失败达到该次数后标记为 dead 不再重试，且不再阻塞同一聚合的后续事件，默认 16
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Outbox) Reset() {
+	*x = Outbox{}
+	mi := &file_conf_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Outbox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Outbox) ProtoMessage() {}
+
+func (x *Outbox) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Outbox.ProtoReflect.Descriptor instead.
+func (*Outbox) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Outbox) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *Outbox) GetPollInterval() *durationpb.Duration {
+	if x != nil {
+		return x.PollInterval
+	}
+	return nil
+}
+
+func (x *Outbox) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *Outbox) GetRetryBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.RetryBackoff
+	}
+	return nil
+}
+
+func (x *Outbox) GetMaxRetryBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.MaxRetryBackoff
+	}
+	return nil
+}
+
+func (x *Outbox) GetRetention() *durationpb.Duration {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+func (x *Outbox) GetStreamPrefix() string {
+	if x != nil {
+		return x.StreamPrefix
+	}
+	return ""
+}
+
+func (x *Outbox) GetStreamMaxLen() int64 {
+	if x != nil {
+		return x.StreamMaxLen
+	}
+	return 0
+}
+
+func (x *Outbox) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+type Sqlite struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 数据库文件路径，":memory:" 为进程内存库（进程退出后数据丢失，启动时自动迁移所有数据表）
+	Path          string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *Sqlite) Reset() {
+	*x = Sqlite{}
+	mi := &file_conf_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sqlite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sqlite) ProtoMessage() {}
+
+func (x *Sqlite) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sqlite.ProtoReflect.Descriptor instead.
+func (*Sqlite) Descriptor() ([]byte, []int) {
+	return file_conf_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Sqlite) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type Postgres struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Dsn   string                 `protobuf:"bytes,1,opt,name=dsn,proto3" json:"dsn,omitempty"`
+	// 只读副本 DSN 列表；配置后查询按轮询分发到副本，写操作、事务内的读以及 biz.WithReadPrimary 标记的读走主库
+	Replicas []string `protobuf:"bytes,2,rep,name=replicas,proto3" json:"replicas,omitempty"`
+	// 副本复制延迟超过该值时 postgres_replicas 检查失败（非关键依赖，整体为 DEGRADED），默认 30s
+	MaxReplicaLag *durationpb.Duration `protobuf:"bytes,3,opt,name=max_replica_lag,json=maxReplicaLag,proto3" json:"max_replica_lag,omitempty"`
+	// 连接池配置，主库与各副本分别生效；未配置时为 max_open_conns=100, max_idle_conns=20, conn_max_lifetime=1800s, conn_max_idle_time=300s
+	MaxOpenConns    int32                `protobuf:"varint,4,opt,name=max_open_conns,json=maxOpenConns,proto3" json:"max_open_conns,omitempty"`
+	MaxIdleConns    int32                `protobuf:"varint,5,opt,name=max_idle_conns,json=maxIdleConns,proto3" json:"max_idle_conns,omitempty"`
+	ConnMaxLifetime *durationpb.Duration `protobuf:"bytes,6,opt,name=conn_max_lifetime,json=connMaxLifetime,proto3" json:"conn_max_lifetime,omitempty"`
+	ConnMaxIdleTime *durationpb.Duration `protobuf:"bytes,7,opt,name=conn_max_idle_time,json=connMaxIdleTime,proto3" json:"conn_max_idle_time,omitempty"`
+	// 单条语句超时（连接参数 statement_timeout），未配置时不限制
+	StatementTimeout *durationpb.Duration `protobuf:"bytes,8,opt,name=statement_timeout,json=statementTimeout,proto3" json:"statement_timeout,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
 func (x *Postgres) Reset() {
 	*x = Postgres{}
-	mi := &file_conf_proto_msgTypes[7]
+	mi := &file_conf_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -579,7 +1511,7 @@ func (x *Postgres) String() string {
 func (*Postgres) ProtoMessage() {}
 
 func (x *Postgres) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_proto_msgTypes[7]
+	mi := &file_conf_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -592,7 +1524,7 @@ func (x *Postgres) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Postgres.ProtoReflect.Descriptor instead.
 func (*Postgres) Descriptor() ([]byte, []int) {
-	return file_conf_proto_rawDescGZIP(), []int{7}
+	return file_conf_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *Postgres) GetDsn() string {
@@ -602,21 +1534,84 @@ func (x *Postgres) GetDsn() string {
 	return ""
 }
 
+func (x *Postgres) GetReplicas() []string {
+	if x != nil {
+		return x.Replicas
+	}
+	return nil
+}
+
+func (x *Postgres) GetMaxReplicaLag() *durationpb.Duration {
+	if x != nil {
+		return x.MaxReplicaLag
+	}
+	return nil
+}
+
+func (x *Postgres) GetMaxOpenConns() int32 {
+	if x != nil {
+		return x.MaxOpenConns
+	}
+	return 0
+}
+
+func (x *Postgres) GetMaxIdleConns() int32 {
+	if x != nil {
+		return x.MaxIdleConns
+	}
+	return 0
+}
+
+func (x *Postgres) GetConnMaxLifetime() *durationpb.Duration {
+	if x != nil {
+		return x.ConnMaxLifetime
+	}
+	return nil
+}
+
+func (x *Postgres) GetConnMaxIdleTime() *durationpb.Duration {
+	if x != nil {
+		return x.ConnMaxIdleTime
+	}
+	return nil
+}
+
+func (x *Postgres) GetStatementTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.StatementTimeout
+	}
+	return nil
+}
+
 var File_conf_proto protoreflect.FileDescriptor
 
 const file_conf_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"conf.proto\x12\x0eapp.app_layout\x1a\x1egoogle/protobuf/duration.proto\"\xba\x01\n" +
+	"conf.proto\x12\x0eapp.app_layout\x1a\x1egoogle/protobuf/duration.proto\"\x9d\x02\n" +
 	"\tBootstrap\x12/\n" +
 	"\x06server\x18\x01 \x01(\v2\x17.app.app_layout.ServersR\x06server\x12%\n" +
 	"\x03log\x18\x02 \x01(\v2\x13.app.app_layout.LogR\x03log\x12(\n" +
-	"\x04data\x18\x03 \x01(\v2\x14.app.app_layout.DataR\x04data\x12+\n" +
+	"\x04data\x18\x03 \x01(\v2\x14.app.app_layout.DataR\x04data\x121\n" +
+	"\ametrics\x18\x04 \x01(\v2\x17.app.app_layout.MetricsR\ametrics\x12.\n" +
+	"\x06health\x18\x05 \x01(\v2\x16.app.app_layout.HealthR\x06health\x12+\n" +
 	"\x05nacos\x18e \x01(\v2\x15.app.app_layout.NacosR\x05nacos\"]\n" +
 	"\fRedisCluster\x12\x14\n" +
 	"\x05seeds\x18\x01 \x03(\tR\x05seeds\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1b\n" +
-	"\tread_only\x18\x03 \x01(\bR\breadOnly\"\xad\x02\n" +
+	"\tread_only\x18\x03 \x01(\bR\breadOnly\"\xc2\x01\n" +
+	"\rRedisSentinel\x12\x1f\n" +
+	"\vmaster_name\x18\x01 \x01(\tR\n" +
+	"masterName\x12\x14\n" +
+	"\x05addrs\x18\x02 \x03(\tR\x05addrs\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x12+\n" +
+	"\x11sentinel_password\x18\x04 \x01(\tR\x10sentinelPassword\x12\x0e\n" +
+	"\x02db\x18\x05 \x01(\x05R\x02db\x12!\n" +
+	"\freplica_read\x18\x06 \x01(\bR\vreplicaRead\"\x8c\x01\n" +
+	"\x05Redis\x12\x10\n" +
+	"\x03dsn\x18\x01 \x01(\tR\x03dsn\x129\n" +
+	"\bsentinel\x18\x02 \x01(\v2\x1d.app.app_layout.RedisSentinelR\bsentinel\x126\n" +
+	"\acluster\x18\x03 \x01(\v2\x1c.app.app_layout.RedisClusterR\acluster\"\xf3\x02\n" +
 	"\x05Nacos\x12\x12\n" +
 	"\x04addr\x18\x01 \x01(\tR\x04addr\x12\x12\n" +
 	"\x04port\x18\x02 \x01(\x04R\x04port\x12\x1a\n" +
@@ -629,7 +1624,13 @@ const file_conf_proto_rawDesc = "" +
 	"\adata_id\x18\t \x01(\tR\x06dataId\x12\x16\n" +
 	"\x06weight\x18\n" +
 	" \x01(\x05R\x06weight\x12\x19\n" +
-	"\bdata_ids\x18\v \x03(\tR\adataIds\"\xa1\x02\n" +
+	"\bdata_ids\x18\v \x03(\tR\adataIds\x12D\n" +
+	"\x0ereadiness_gate\x18\f \x01(\v2\x1d.app.app_layout.ReadinessGateR\rreadinessGate\"\x9b\x01\n" +
+	"\rReadinessGate\x12\x1a\n" +
+	"\bdisabled\x18\x01 \x01(\bR\bdisabled\x128\n" +
+	"\n" +
+	"down_after\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\tdownAfter\x124\n" +
+	"\bup_after\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\aupAfter\"\xa1\x02\n" +
 	"\x03Log\x12&\n" +
 	"\x0fdisable_std_log\x18\x01 \x01(\bR\rdisableStdLog\x12\x17\n" +
 	"\alog_dir\x18\x02 \x01(\tR\x06logDir\x12!\n" +
@@ -642,20 +1643,96 @@ const file_conf_proto_rawDesc = "" +
 	"\fmax_age_days\x18\f \x01(\x05R\n" +
 	"maxAgeDays\x12\x1a\n" +
 	"\bcompress\x18\r \x01(\bR\bcompress\x12!\n" +
-	"\frotate_daily\x18\x0e \x01(\bR\vrotateDaily\"a\n" +
+	"\frotate_daily\x18\x0e \x01(\bR\vrotateDaily\"\xc8\x01\n" +
+	"\aMetrics\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12K\n" +
+	"\fconst_labels\x18\x02 \x03(\v2(.app.app_layout.Metrics.ConstLabelsEntryR\vconstLabels\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x1a>\n" +
+	"\x10ConstLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x85\x03\n" +
+	"\x06Health\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\x123\n" +
+	"\atimeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12+\n" +
+	"\x11failure_threshold\x18\x03 \x01(\x05R\x10failureThreshold\x12+\n" +
+	"\x11success_threshold\x18\x04 \x01(\x05R\x10successThreshold\x12!\n" +
+	"\fhistory_size\x18\x05 \x01(\x05R\vhistorySize\x12:\n" +
+	"\x06checks\x18\x06 \x03(\v2\".app.app_layout.Health.ChecksEntryR\x06checks\x1aV\n" +
+	"\vChecksEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x121\n" +
+	"\x05value\x18\x02 \x01(\v2\x1b.app.app_layout.HealthCheckR\x05value:\x028\x01\"]\n" +
+	"\vHealthCheck\x12\x1f\n" +
+	"\bcritical\x18\x01 \x01(\bH\x00R\bcritical\x88\x01\x01\x12\x17\n" +
+	"\x04slow\x18\x02 \x01(\bH\x01R\x04slow\x88\x01\x01B\v\n" +
+	"\t_criticalB\a\n" +
+	"\x05_slow\"\xda\x01\n" +
 	"\aServers\x12*\n" +
 	"\x04grpc\x18\x01 \x01(\v2\x16.app.app_layout.ServerR\x04grpc\x12*\n" +
-	"\x04http\x18\x02 \x01(\v2\x16.app.app_layout.ServerR\x04http\"\x94\x01\n" +
+	"\x04http\x18\x02 \x01(\v2\x16.app.app_layout.ServerR\x04http\x128\n" +
+	"\n" +
+	"access_log\x18\x03 \x01(\v2\x19.app.app_layout.AccessLogR\taccessLog\x12=\n" +
+	"\vidempotency\x18\x04 \x01(\v2\x1b.app.app_layout.IdempotencyR\vidempotency\"\xb1\x01\n" +
+	"\vIdempotency\x12\x1e\n" +
+	"\n" +
+	"operations\x18\x01 \x03(\tR\n" +
+	"operations\x12+\n" +
+	"\x03ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x124\n" +
+	"\block_ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\alockTtl\x12\x1f\n" +
+	"\vrequire_key\x18\x04 \x01(\bR\n" +
+	"requireKey\"\x8f\x01\n" +
+	"\tAccessLog\x12-\n" +
+	"\x12exclude_operations\x18\x01 \x03(\tR\x11excludeOperations\x12#\n" +
+	"\rexclude_paths\x18\x02 \x03(\tR\fexcludePaths\x12.\n" +
+	"\x13success_sample_rate\x18\x03 \x01(\x01R\x11successSampleRate\"\xf1\x01\n" +
 	"\x06Server\x12\x12\n" +
 	"\x04addr\x18\x01 \x01(\tR\x04addr\x12\x18\n" +
 	"\anetwork\x18\x02 \x01(\tR\anetwork\x123\n" +
 	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12'\n" +
-	"\x0fpublic_endpoint\x18\x04 \x01(\tR\x0epublicEndpoint\"\x7f\n" +
+	"\x0fpublic_endpoint\x18\x04 \x01(\tR\x0epublicEndpoint\x121\n" +
+	"\aopenapi\x18\x05 \x01(\v2\x17.app.app_layout.OpenAPIR\aopenapi\x12(\n" +
+	"\x04json\x18\x06 \x01(\v2\x14.app.app_layout.JSONR\x04json\"\x8b\x02\n" +
+	"\x04JSON\x12+\n" +
+	"\x0fuse_proto_names\x18\x01 \x01(\bH\x00R\ruseProtoNames\x88\x01\x01\x12.\n" +
+	"\x10emit_unpopulated\x18\x02 \x01(\bH\x01R\x0femitUnpopulated\x88\x01\x01\x12)\n" +
+	"\x0eenum_as_number\x18\x03 \x01(\bH\x02R\fenumAsNumber\x88\x01\x01\x12+\n" +
+	"\x0fint64_as_string\x18\x04 \x01(\bH\x03R\rint64AsString\x88\x01\x01B\x12\n" +
+	"\x10_use_proto_namesB\x13\n" +
+	"\x11_emit_unpopulatedB\x11\n" +
+	"\x0f_enum_as_numberB\x12\n" +
+	"\x10_int64_as_string\"j\n" +
+	"\aOpenAPI\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x1b\n" +
+	"\tdocs_path\x18\x03 \x01(\tR\bdocsPath\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\"\x8c\x02\n" +
 	"\x04Data\x124\n" +
 	"\bpostgres\x18\x01 \x01(\v2\x18.app.app_layout.PostgresR\bpostgres\x12A\n" +
-	"\rredis_cluster\x18\x02 \x01(\v2\x1c.app.app_layout.RedisClusterR\fredisCluster\"\x1c\n" +
+	"\rredis_cluster\x18\x02 \x01(\v2\x1c.app.app_layout.RedisClusterR\fredisCluster\x12+\n" +
+	"\x05redis\x18\x03 \x01(\v2\x15.app.app_layout.RedisR\x05redis\x12.\n" +
+	"\x06sqlite\x18\x04 \x01(\v2\x16.app.app_layout.SqliteR\x06sqlite\x12.\n" +
+	"\x06outbox\x18\x05 \x01(\v2\x16.app.app_layout.OutboxR\x06outbox\"\xb1\x03\n" +
+	"\x06Outbox\x12\x1a\n" +
+	"\bdisabled\x18\x01 \x01(\bR\bdisabled\x12>\n" +
+	"\rpoll_interval\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\fpollInterval\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x03 \x01(\x05R\tbatchSize\x12>\n" +
+	"\rretry_backoff\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fretryBackoff\x12E\n" +
+	"\x11max_retry_backoff\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x0fmaxRetryBackoff\x127\n" +
+	"\tretention\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\tretention\x12#\n" +
+	"\rstream_prefix\x18\a \x01(\tR\fstreamPrefix\x12$\n" +
+	"\x0estream_max_len\x18\b \x01(\x03R\fstreamMaxLen\x12!\n" +
+	"\fmax_attempts\x18\t \x01(\x05R\vmaxAttempts\"\x1c\n" +
+	"\x06Sqlite\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\"\x9e\x03\n" +
 	"\bPostgres\x12\x10\n" +
-	"\x03dsn\x18\x01 \x01(\tR\x03dsnB\x0fZ\rinternal/confb\x06proto3"
+	"\x03dsn\x18\x01 \x01(\tR\x03dsn\x12\x1a\n" +
+	"\breplicas\x18\x02 \x03(\tR\breplicas\x12A\n" +
+	"\x0fmax_replica_lag\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\rmaxReplicaLag\x12$\n" +
+	"\x0emax_open_conns\x18\x04 \x01(\x05R\fmaxOpenConns\x12$\n" +
+	"\x0emax_idle_conns\x18\x05 \x01(\x05R\fmaxIdleConns\x12E\n" +
+	"\x11conn_max_lifetime\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x0fconnMaxLifetime\x12F\n" +
+	"\x12conn_max_idle_time\x18\a \x01(\v2\x19.google.protobuf.DurationR\x0fconnMaxIdleTime\x12F\n" +
+	"\x11statement_timeout\x18\b \x01(\v2\x19.google.protobuf.DurationR\x10statementTimeoutB\x0fZ\rinternal/confb\x06proto3"
 
 var (
 	file_conf_proto_rawDescOnce sync.Once
@@ -669,33 +1746,76 @@ func file_conf_proto_rawDescGZIP() []byte {
 	return file_conf_proto_rawDescData
 }
 
-var file_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_conf_proto_goTypes = []any{
 	(*Bootstrap)(nil),           // 0: app.app_layout.Bootstrap
 	(*RedisCluster)(nil),        // 1: app.app_layout.RedisCluster
-	(*Nacos)(nil),               // 2: app.app_layout.Nacos
-	(*Log)(nil),                 // 3: app.app_layout.Log
-	(*Servers)(nil),             // 4: app.app_layout.Servers
-	(*Server)(nil),              // 5: app.app_layout.Server
-	(*Data)(nil),                // 6: app.app_layout.Data
-	(*Postgres)(nil),            // 7: app.app_layout.Postgres
-	(*durationpb.Duration)(nil), // 8: google.protobuf.Duration
+	(*RedisSentinel)(nil),       // 2: app.app_layout.RedisSentinel
+	(*Redis)(nil),               // 3: app.app_layout.Redis
+	(*Nacos)(nil),               // 4: app.app_layout.Nacos
+	(*ReadinessGate)(nil),       // 5: app.app_layout.ReadinessGate
+	(*Log)(nil),                 // 6: app.app_layout.Log
+	(*Metrics)(nil),             // 7: app.app_layout.Metrics
+	(*Health)(nil),              // 8: app.app_layout.Health
+	(*HealthCheck)(nil),         // 9: app.app_layout.HealthCheck
+	(*Servers)(nil),             // 10: app.app_layout.Servers
+	(*Idempotency)(nil),         // 11: app.app_layout.Idempotency
+	(*AccessLog)(nil),           // 12: app.app_layout.AccessLog
+	(*Server)(nil),              // 13: app.app_layout.Server
+	(*JSON)(nil),                // 14: app.app_layout.JSON
+	(*OpenAPI)(nil),             // 15: app.app_layout.OpenAPI
+	(*Data)(nil),                // 16: app.app_layout.Data
+	(*Outbox)(nil),              // 17: app.app_layout.Outbox
+	(*Sqlite)(nil),              // 18: app.app_layout.Sqlite
+	(*Postgres)(nil),            // 19: app.app_layout.Postgres
+	nil,                         // 20: app.app_layout.Metrics.ConstLabelsEntry
+	nil,                         // 21: app.app_layout.Health.ChecksEntry
+	(*durationpb.Duration)(nil), // 22: google.protobuf.Duration
 }
 var file_conf_proto_depIdxs = []int32{
-	4, // 0: app.app_layout.Bootstrap.server:type_name -> app.app_layout.Servers
-	3, // 1: app.app_layout.Bootstrap.log:type_name -> app.app_layout.Log
-	6, // 2: app.app_layout.Bootstrap.data:type_name -> app.app_layout.Data
-	2, // 3: app.app_layout.Bootstrap.nacos:type_name -> app.app_layout.Nacos
-	5, // 4: app.app_layout.Servers.grpc:type_name -> app.app_layout.Server
-	5, // 5: app.app_layout.Servers.http:type_name -> app.app_layout.Server
-	8, // 6: app.app_layout.Server.timeout:type_name -> google.protobuf.Duration
-	7, // 7: app.app_layout.Data.postgres:type_name -> app.app_layout.Postgres
-	1, // 8: app.app_layout.Data.redis_cluster:type_name -> app.app_layout.RedisCluster
-	9, // [9:9] is the sub-list for method output_type
-	9, // [9:9] is the sub-list for method input_type
-	9, // [9:9] is the sub-list for extension type_name
-	9, // [9:9] is the sub-list for extension extendee
-	0, // [0:9] is the sub-list for field type_name
+	10, // 0: app.app_layout.Bootstrap.server:type_name -> app.app_layout.Servers
+	6,  // 1: app.app_layout.Bootstrap.log:type_name -> app.app_layout.Log
+	16, // 2: app.app_layout.Bootstrap.data:type_name -> app.app_layout.Data
+	7,  // 3: app.app_layout.Bootstrap.metrics:type_name -> app.app_layout.Metrics
+	8,  // 4: app.app_layout.Bootstrap.health:type_name -> app.app_layout.Health
+	4,  // 5: app.app_layout.Bootstrap.nacos:type_name -> app.app_layout.Nacos
+	2,  // 6: app.app_layout.Redis.sentinel:type_name -> app.app_layout.RedisSentinel
+	1,  // 7: app.app_layout.Redis.cluster:type_name -> app.app_layout.RedisCluster
+	5,  // 8: app.app_layout.Nacos.readiness_gate:type_name -> app.app_layout.ReadinessGate
+	22, // 9: app.app_layout.ReadinessGate.down_after:type_name -> google.protobuf.Duration
+	22, // 10: app.app_layout.ReadinessGate.up_after:type_name -> google.protobuf.Duration
+	20, // 11: app.app_layout.Metrics.const_labels:type_name -> app.app_layout.Metrics.ConstLabelsEntry
+	22, // 12: app.app_layout.Health.interval:type_name -> google.protobuf.Duration
+	22, // 13: app.app_layout.Health.timeout:type_name -> google.protobuf.Duration
+	21, // 14: app.app_layout.Health.checks:type_name -> app.app_layout.Health.ChecksEntry
+	13, // 15: app.app_layout.Servers.grpc:type_name -> app.app_layout.Server
+	13, // 16: app.app_layout.Servers.http:type_name -> app.app_layout.Server
+	12, // 17: app.app_layout.Servers.access_log:type_name -> app.app_layout.AccessLog
+	11, // 18: app.app_layout.Servers.idempotency:type_name -> app.app_layout.Idempotency
+	22, // 19: app.app_layout.Idempotency.ttl:type_name -> google.protobuf.Duration
+	22, // 20: app.app_layout.Idempotency.lock_ttl:type_name -> google.protobuf.Duration
+	22, // 21: app.app_layout.Server.timeout:type_name -> google.protobuf.Duration
+	15, // 22: app.app_layout.Server.openapi:type_name -> app.app_layout.OpenAPI
+	14, // 23: app.app_layout.Server.json:type_name -> app.app_layout.JSON
+	19, // 24: app.app_layout.Data.postgres:type_name -> app.app_layout.Postgres
+	1,  // 25: app.app_layout.Data.redis_cluster:type_name -> app.app_layout.RedisCluster
+	3,  // 26: app.app_layout.Data.redis:type_name -> app.app_layout.Redis
+	18, // 27: app.app_layout.Data.sqlite:type_name -> app.app_layout.Sqlite
+	17, // 28: app.app_layout.Data.outbox:type_name -> app.app_layout.Outbox
+	22, // 29: app.app_layout.Outbox.poll_interval:type_name -> google.protobuf.Duration
+	22, // 30: app.app_layout.Outbox.retry_backoff:type_name -> google.protobuf.Duration
+	22, // 31: app.app_layout.Outbox.max_retry_backoff:type_name -> google.protobuf.Duration
+	22, // 32: app.app_layout.Outbox.retention:type_name -> google.protobuf.Duration
+	22, // 33: app.app_layout.Postgres.max_replica_lag:type_name -> google.protobuf.Duration
+	22, // 34: app.app_layout.Postgres.conn_max_lifetime:type_name -> google.protobuf.Duration
+	22, // 35: app.app_layout.Postgres.conn_max_idle_time:type_name -> google.protobuf.Duration
+	22, // 36: app.app_layout.Postgres.statement_timeout:type_name -> google.protobuf.Duration
+	9,  // 37: app.app_layout.Health.ChecksEntry.value:type_name -> app.app_layout.HealthCheck
+	38, // [38:38] is the sub-list for method output_type
+	38, // [38:38] is the sub-list for method input_type
+	38, // [38:38] is the sub-list for extension type_name
+	38, // [38:38] is the sub-list for extension extendee
+	0,  // [0:38] is the sub-list for field type_name
 }
 
 func init() { file_conf_proto_init() }
@@ -703,13 +1823,15 @@ func file_conf_proto_init() {
 	if File_conf_proto != nil {
 		return
 	}
+	file_conf_proto_msgTypes[9].OneofWrappers = []any{}
+	file_conf_proto_msgTypes[14].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_conf_proto_rawDesc), len(file_conf_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
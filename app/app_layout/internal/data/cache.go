@@ -0,0 +1,236 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/biz"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/metricx"
+)
+
+const (
+	cacheKeyPrefix = "app_layout:cache:"
+	cacheNegative  = "\x00nil" // 负缓存标记，JSON 编码结果不会以 \x00 开头
+
+	defaultCacheTTL         = 10 * time.Minute
+	defaultCacheJitter      = 0.1
+	defaultCacheLoadTimeout = 10 * time.Second
+)
+
+// 缓存请求结果，cache_requests_total 的 result 标签
+const (
+	cacheHit         = "hit"
+	cacheMiss        = "miss"
+	cacheNegativeHit = "negative_hit"
+	cacheError       = "error" // Redis 读写失败，已降级为直接加载
+)
+
+// CacheMetrics 缓存指标，所有 Cache 共用：
+// - cache_requests_total{cache, result}：读取次数，result 为 hit / miss / negative_hit / error
+// - cache_load_duration_seconds{cache}：未命中时回源加载耗时
+type CacheMetrics struct {
+	requests *prometheus.CounterVec
+	loads    *prometheus.HistogramVec
+}
+
+func NewCacheMetrics(mr *metricx.Registry) (*CacheMetrics, error) {
+	m := &CacheMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: mr.Namespace(),
+			Name:      "cache_requests_total",
+			Help:      "Number of read-through cache lookups by result.",
+		}, []string{"cache", "result"}),
+		loads: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: mr.Namespace(),
+			Name:      "cache_load_duration_seconds",
+			Help:      "Latency of loading values from the source on cache miss.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"cache"}),
+	}
+	if err := mr.Register(m.requests, m.loads); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CacheOption Cache 选项
+type CacheOption func(*cacheOptions)
+
+type cacheOptions struct {
+	ttl         time.Duration
+	jitter      float64
+	notFound    error
+	negativeTTL time.Duration
+	loadTimeout time.Duration
+}
+
+// WithCacheTTL 缓存有效期，默认 10m
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return func(o *cacheOptions) { o.ttl = ttl }
+}
+
+// WithCacheJitter 有效期随机浮动比例 [0, 1)，避免同批写入的 key 同时过期，默认 0.1
+func WithCacheJitter(jitter float64) CacheOption {
+	return func(o *cacheOptions) { o.jitter = jitter }
+}
+
+// WithCacheLoadTimeout 回源超时，默认 10s；回源不随调用方取消，需独立的超时避免 load 阻塞时永久占用 singleflight
+func WithCacheLoadTimeout(timeout time.Duration) CacheOption {
+	return func(o *cacheOptions) { o.loadTimeout = timeout }
+}
+
+// WithNegativeCache 加载返回 notFound（errors.Is 判定）时缓存“不存在”ttl 时长，期间直接返回 notFound，防止缓存穿透
+func WithNegativeCache(notFound error, ttl time.Duration) CacheOption {
+	return func(o *cacheOptions) {
+		o.notFound = notFound
+		o.negativeTTL = ttl
+	}
+}
+
+// Cache 基于 Redis 的读穿透缓存，值以 JSON 序列化：
+// - Get 未命中时调用 load 回源并回写，同一进程内相同 key 的并发回源通过 singleflight 合并
+// - Redis 不可用或未配置时降级为直接回源，不影响业务
+// - 写操作后调用 Invalidate 删除缓存；在 InTx 事务中时提交后会再次删除，避免提交前被并发读回填旧值
+// - 合并回源时各调用方拿到结果的浅拷贝，T 中的 slice / map / 指针字段仍然共享，调用方不应修改
+type Cache[T any] struct {
+	data    *Data
+	name    string
+	opts    cacheOptions
+	metrics *CacheMetrics
+	group   singleflight.Group
+	log     *log.Helper
+}
+
+// NewCache name 用于 key 前缀与指标标签，在应用内唯一，如 "hello"
+func NewCache[T any](data *Data, cm *CacheMetrics, name string, logger log.Logger, opts ...CacheOption) *Cache[T] {
+	c := &Cache[T]{
+		data:    data,
+		name:    name,
+		opts:    cacheOptions{ttl: defaultCacheTTL, jitter: defaultCacheJitter, loadTimeout: defaultCacheLoadTimeout},
+		metrics: cm,
+		log:     log.NewHelper(log.With(logger, "module", "app_layout/cache", "cache", name)),
+	}
+	for _, o := range opts {
+		o(&c.opts)
+	}
+	return c
+}
+
+func (c *Cache[T]) key(key string) string {
+	return cacheKeyPrefix + c.name + ":" + key
+}
+
+// Get 读取缓存，未命中时通过 load 加载并写入缓存；
+// ctx 处于 InTx 事务中时直接调用 load，避免读到并缓存未提交的数据
+func (c *Cache[T]) Get(ctx context.Context, key string, load func(ctx context.Context) (*T, error)) (*T, error) {
+	if c.data.inTx(ctx) || c.data.rdb == nil {
+		return load(ctx)
+	}
+
+	raw, err := c.data.rdb.Get(ctx, c.key(key)).Result()
+	switch {
+	case err == nil:
+		if raw == cacheNegative && c.opts.notFound != nil {
+			c.observe(cacheNegativeHit)
+			return nil, c.opts.notFound
+		}
+		v := new(T)
+		if err := json.Unmarshal([]byte(raw), v); err == nil {
+			c.observe(cacheHit)
+			return v, nil
+		}
+		c.log.WithContext(ctx).Warnf("decode cached %s failed, reloading: %v", key, err)
+		c.observe(cacheError)
+	case errors.Is(err, redis.Nil):
+		c.observe(cacheMiss)
+	default:
+		c.log.WithContext(ctx).Warnf("get cached %s failed, loading from source: %v", key, err)
+		c.observe(cacheError)
+	}
+
+	v, err, shared := c.group.Do(key, func() (any, error) {
+		// 回源不受单个调用方取消的影响，避免一个调用方超时导致合并的其他调用方一起失败；
+		// 回源读主库，避免 Invalidate 后从延迟的副本读到旧值并回填
+		lctx, cancel := context.WithTimeout(biz.WithReadPrimary(context.WithoutCancel(ctx)), c.opts.loadTimeout)
+		defer cancel()
+		start := time.Now()
+		v, err := load(lctx)
+		c.metrics.loads.WithLabelValues(c.name).Observe(time.Since(start).Seconds())
+		switch {
+		case err == nil:
+			c.set(lctx, key, v)
+		case c.opts.notFound != nil && errors.Is(err, c.opts.notFound):
+			c.setRaw(lctx, key, cacheNegative, c.opts.negativeTTL)
+		}
+		return v, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if p := v.(*T); shared && p != nil {
+		// 合并的调用方各自持有副本，避免一方修改影响其他调用方
+		cp := *p
+		return &cp, nil
+	}
+	return v.(*T), nil
+}
+
+// Set 主动写入缓存，失败仅记录日志
+func (c *Cache[T]) Set(ctx context.Context, key string, v *T) {
+	c.set(ctx, key, v)
+}
+
+func (c *Cache[T]) set(ctx context.Context, key string, v *T) {
+	if c.data.rdb == nil {
+		return
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		c.log.WithContext(ctx).Warnf("encode %s failed: %v", key, err)
+		return
+	}
+	c.setRaw(ctx, key, string(b), c.opts.ttl)
+}
+
+func (c *Cache[T]) setRaw(ctx context.Context, key, raw string, ttl time.Duration) {
+	if c.opts.jitter > 0 {
+		ttl += time.Duration(rand.Float64() * c.opts.jitter * float64(ttl))
+	}
+	if err := c.data.rdb.Set(ctx, c.key(key), raw, ttl).Err(); err != nil {
+		c.log.WithContext(ctx).Warnf("set cached %s failed: %v", key, err)
+	}
+}
+
+// Invalidate 删除缓存，写操作成功后调用；ctx 处于 InTx 事务中时，事务提交后会再次删除
+func (c *Cache[T]) Invalidate(ctx context.Context, keys ...string) {
+	if c.data.rdb == nil {
+		return
+	}
+	c.del(ctx, keys)
+	if c.data.inTx(ctx) {
+		c.data.AfterCommit(ctx, func(ctx context.Context) { c.del(ctx, keys) })
+	}
+}
+
+func (c *Cache[T]) del(ctx context.Context, keys []string) {
+	// 集群模式下多个 key 可能位于不同 slot，逐个删除，由 pipeline 按节点合并请求
+	pipe := c.data.rdb.Pipeline()
+	for _, k := range keys {
+		pipe.Del(ctx, c.key(k))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		c.log.WithContext(ctx).Errorf("invalidate %v failed: %v", keys, err)
+	}
+}
+
+func (c *Cache[T]) observe(result string) {
+	c.metrics.requests.WithLabelValues(c.name, result).Inc()
+}
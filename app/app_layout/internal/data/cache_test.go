@@ -0,0 +1,207 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type cacheItem struct {
+	Name string
+}
+
+var errItemNotFound = errors.New("item not found")
+
+func newTestCache(t *testing.T, d *Data, opts ...CacheOption) *Cache[cacheItem] {
+	t.Helper()
+	cm, err := NewCacheMetrics(newTestMetrics(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewCache[cacheItem](d, cm, "item", log.DefaultLogger, opts...)
+}
+
+func TestCacheReadThrough(t *testing.T) {
+	d, mr := newTestData(t)
+	c := newTestCache(t, d)
+	ctx := context.Background()
+
+	var loads atomic.Int32
+	load := func(context.Context) (*cacheItem, error) {
+		loads.Add(1)
+		return &cacheItem{Name: "a"}, nil
+	}
+	for range 3 {
+		v, err := c.Get(ctx, "1", load)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.Name != "a" {
+			t.Fatalf("got %q, want %q", v.Name, "a")
+		}
+	}
+	if n := loads.Load(); n != 1 {
+		t.Fatalf("loaded %d times, want 1", n)
+	}
+	if !mr.Exists(c.key("1")) {
+		t.Fatal("value is not written to redis")
+	}
+	if ttl := mr.TTL(c.key("1")); ttl < defaultCacheTTL || ttl > defaultCacheTTL+time.Duration(defaultCacheJitter*float64(defaultCacheTTL)) {
+		t.Fatalf("ttl %s out of range", ttl)
+	}
+}
+
+func TestCacheNegative(t *testing.T) {
+	d, _ := newTestData(t)
+	c := newTestCache(t, d, WithNegativeCache(errItemNotFound, time.Minute))
+	ctx := context.Background()
+
+	var loads atomic.Int32
+	load := func(context.Context) (*cacheItem, error) {
+		loads.Add(1)
+		return nil, errItemNotFound
+	}
+	for range 3 {
+		if _, err := c.Get(ctx, "1", load); !errors.Is(err, errItemNotFound) {
+			t.Fatalf("got %v, want %v", err, errItemNotFound)
+		}
+	}
+	if n := loads.Load(); n != 1 {
+		t.Fatalf("loaded %d times, want 1", n)
+	}
+
+	// 写入后失效负缓存，再次读取回源
+	c.Invalidate(ctx, "1")
+	v, err := c.Get(ctx, "1", func(context.Context) (*cacheItem, error) { return &cacheItem{Name: "b"}, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Name != "b" {
+		t.Fatalf("got %q, want %q", v.Name, "b")
+	}
+}
+
+func TestCacheSingleflight(t *testing.T) {
+	d, _ := newTestData(t)
+	c := newTestCache(t, d)
+	ctx := context.Background()
+
+	const callers = 10
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func(context.Context) (*cacheItem, error) {
+		loads.Add(1)
+		<-release
+		return &cacheItem{Name: "a"}, nil
+	}
+
+	results := make([]*cacheItem, callers)
+	var wg sync.WaitGroup
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.Get(ctx, "1", load)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			results[i] = v
+		}()
+	}
+	// 等待所有调用方进入 singleflight 后再完成回源
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := loads.Load(); n != 1 {
+		t.Fatalf("loaded %d times, want 1", n)
+	}
+	seen := make(map[*cacheItem]struct{})
+	for _, v := range results {
+		if v == nil || v.Name != "a" {
+			t.Fatalf("unexpected result %+v", v)
+		}
+		seen[v] = struct{}{}
+	}
+	if len(seen) != callers {
+		t.Fatalf("callers share %d values, want %d copies", len(seen), callers)
+	}
+}
+
+func TestCacheLoadTimeout(t *testing.T) {
+	d, _ := newTestData(t)
+	c := newTestCache(t, d, WithCacheLoadTimeout(50*time.Millisecond))
+
+	_, err := c.Get(context.Background(), "1", func(ctx context.Context) (*cacheItem, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestCacheInvalidateAfterCommit(t *testing.T) {
+	d, mr := newTestData(t)
+	c := newTestCache(t, d)
+	ctx := context.Background()
+	item := &cacheItem{Name: "old"}
+
+	c.Set(ctx, "1", item)
+	err := d.InTx(ctx, func(ctx context.Context) error {
+		c.Invalidate(ctx, "1")
+		// 模拟提交前并发读回填旧值
+		c.Set(context.Background(), "1", item)
+		if !mr.Exists(c.key("1")) {
+			t.Fatal("value should be backfilled before commit")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mr.Exists(c.key("1")) {
+		t.Fatal("value should be invalidated after commit")
+	}
+
+	// 回滚时不执行提交后的失效
+	errRollback := errors.New("rollback")
+	err = d.InTx(ctx, func(ctx context.Context) error {
+		c.Invalidate(ctx, "1")
+		c.Set(context.Background(), "1", item)
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("got %v, want %v", err, errRollback)
+	}
+	if !mr.Exists(c.key("1")) {
+		t.Fatal("invalidate hook should not run after rollback")
+	}
+}
+
+func TestCacheWithoutRedis(t *testing.T) {
+	d, _ := newTestData(t)
+	d.rdb = nil
+	c := newTestCache(t, d)
+
+	var loads atomic.Int32
+	load := func(context.Context) (*cacheItem, error) {
+		loads.Add(1)
+		return &cacheItem{Name: "a"}, nil
+	}
+	for range 2 {
+		if _, err := c.Get(context.Background(), "1", load); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.Invalidate(context.Background(), "1")
+	if n := loads.Load(); n != 2 {
+		t.Fatalf("loaded %d times, want 2", n)
+	}
+}
@@ -3,57 +3,112 @@ package data
 import (
 	"context"
 
+	kerrors "github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/wire"
-	"github.com/jeffinity/singularity/friendly"
 	"github.com/jeffinity/singularity/migratex"
-	"github.com/jeffinity/singularity/pgx"
+	"github.com/pkg/errors"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
 
 	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/health"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/metricx"
 )
 
 // ProviderSet is data providers.
 var ProviderSet = wire.NewSet(
 	NewData,
-	NewPostgres,
+	NewDB,
+	NewReplicas,
 	NewRedis,
+	NewTransaction,
+	NewCacheMetrics,
 	NewHelloRepo,
+	NewIdempotencyRepo,
+	NewLocker,
+	NewOutbox,
+	NewRedisStreamSink,
+	NewOutboxRelay,
 	NewAllMigrator,
 )
 
-// Data .
+var (
+	// ErrDatabaseNotConfigured 未配置 data.postgres.dsn / data.sqlite 时访问数据库返回
+	ErrDatabaseNotConfigured = kerrors.ServiceUnavailable("DATABASE_NOT_CONFIGURED", "database is not configured, set data.postgres.dsn or data.sqlite")
+	// ErrRedisNotConfigured 未配置 data.redis 时访问 Redis 返回
+	ErrRedisNotConfigured = kerrors.ServiceUnavailable("REDIS_NOT_CONFIGURED", "redis is not configured, set data.redis")
+)
+
+// Data 数据层依赖，数据库（Postgres 或 SQLite）/ Redis 均按配置可选，未配置时为 nil，
+// repo 通过 DB / Redis 获取，依赖缺失时返回 ErrDatabaseNotConfigured / ErrRedisNotConfigured
 type Data struct {
 	pg  *gorm.DB
-	rdb *redis.ClusterClient
+	rdb redis.UniversalClient
+
+	outboxNotify chan struct{} // outbox 事件提交后唤醒 relay
 }
 
+// NewAllMigrator 未配置数据库时返回 nil，migrate 命令据此提示不支持迁移
 func NewAllMigrator(data *Data) *migratex.Migrator {
-	return migratex.NewAllMigrator(data.pg, []any{
+	if data.pg == nil {
+		return nil
+	}
+	// 迁移中的 schema 查询同样需要走主库
+	return migratex.NewAllMigrator(data.pg.Clauses(dbresolver.Write), []any{
 		&Hello{},
+		&OutboxEvent{},
 	})
 }
 
 // NewData .
-func NewData(c *conf.Bootstrap, pg *gorm.DB, rdb *redis.ClusterClient, logger log.Logger) (*Data, func(), error) {
+func NewData(c *conf.Bootstrap, pg *gorm.DB, replicas *Replicas, rdb redis.UniversalClient, hr *health.Registry, mr *metricx.Registry, logger log.Logger) (*Data, func(), error) {
 
 	mLog := log.NewHelper(log.With(logger, "module", "app_layout/data"))
 	cleanup := func() {
 		mLog.Info("closing the data resources")
 	}
 
-	return &Data{
-		pg:  pg,
-		rdb: rdb,
-	}, cleanup, nil
-}
-
-func NewPostgres(c *conf.Bootstrap, logger log.Logger) (*gorm.DB, error) {
-	return pgx.NewPostgres(c.GetLog().GetLevel(), c.GetData().GetPostgres().GetDsn(), logger)
+	d := &Data{
+		pg:           pg,
+		rdb:          rdb,
+		outboxNotify: make(chan struct{}, 1),
+	}
+	// 仅为已配置的依赖注册检查项与指标
+	if pg != nil {
+		if err := hr.Register(d.dbChecker(c)); err != nil {
+			return nil, nil, err
+		}
+		if err := registerPoolMetrics(mr, pg, replicas); err != nil {
+			return nil, nil, err
+		}
+	}
+	if rdb != nil {
+		if err := hr.Register(health.CheckerFunc("redis", d.pingRedis, health.WithMetadata(d.redisMetadata(c)))); err != nil {
+			return nil, nil, err
+		}
+	}
+	// 内存库随进程创建，无法预先执行 migrate 命令，启动时自动迁移
+	if pg != nil && isMemorySqlite(c.GetData().GetSqlite().GetPath()) {
+		if err := NewAllMigrator(d).MigrateAll(context.Background()); err != nil {
+			return nil, nil, errors.WithMessage(err, "migrate sqlite memory database failed:")
+		}
+	}
+	if len(replicas.dbs) > 0 {
+		// 副本不可用或延迟过大时查询仍可能落到副本，仅标记为 DEGRADED，不影响 Readiness
+		if err := hr.Register(health.CheckerFunc("postgres_replicas", replicas.check,
+			health.Optional(), health.WithMetadata(replicas.metadata))); err != nil {
+			return nil, nil, err
+		}
+	}
+	return d, cleanup, nil
 }
 
-func NewRedis(rootCtx context.Context, c *conf.Bootstrap, mLogger log.Logger) (*redis.ClusterClient, func(), error) {
-	rc := c.GetData().GetRedisCluster()
-	return friendly.NewRedisCluster(rootCtx, mLogger, rc.GetSeeds(), rc.GetPassword(), rc.GetReadOnly())
+// Redis 返回 Redis 客户端，未配置时返回 ErrRedisNotConfigured
+func (d *Data) Redis() (redis.UniversalClient, error) {
+	if d.rdb == nil {
+		return nil, ErrRedisNotConfigured
+	}
+	return d.rdb, nil
 }
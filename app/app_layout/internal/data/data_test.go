@@ -0,0 +1,53 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/app_init"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/health"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/metricx"
+)
+
+// newTestData 基于 SQLite 内存库与 miniredis 构建 Data，测试结束后自动关闭
+func newTestData(t *testing.T) (*Data, *miniredis.Miniredis) {
+	t.Helper()
+	c := &conf.Bootstrap{Data: &conf.Data{Sqlite: &conf.Sqlite{Path: ":memory:"}}}
+	logger := log.NewFilter(log.DefaultLogger, log.FilterLevel(log.LevelError))
+
+	db, err := NewDB(c, &Replicas{}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	// 内存库由 NewData 自动迁移
+	d, cleanup, err := NewData(c, db, &Replicas{}, rdb, health.NewRegistry(c), newTestMetrics(t), logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(cleanup)
+	return d, mr
+}
+
+func newTestMetrics(t *testing.T) *metricx.Registry {
+	t.Helper()
+	mr, cleanup, err := metricx.NewRegistry(app_init.AppInfo{}, &conf.Bootstrap{}, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(cleanup)
+	return mr
+}
@@ -0,0 +1,114 @@
+package data
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/health"
+)
+
+// dbChecker 数据库检查项，按所用驱动命名为 "postgres" 或 "sqlite"
+func (d *Data) dbChecker(c *conf.Bootstrap) health.Checker {
+	if c.GetData().GetSqlite() != nil {
+		return health.CheckerFunc("sqlite", d.pingDB, health.WithMetadata(d.sqliteMetadata(c)))
+	}
+	return health.CheckerFunc("postgres", d.pingDB, health.WithMetadata(d.postgresMetadata(c)))
+}
+
+func (d *Data) pingDB(ctx context.Context) error {
+	sqlDB, err := d.pg.DB()
+	if err != nil {
+		return errors.WithMessage(err, "get sql db:")
+	}
+	return errors.WithMessage(sqlDB.PingContext(ctx), "ping:")
+}
+
+func (d *Data) pingRedis(ctx context.Context) error {
+	return errors.WithMessage(d.rdb.Ping(ctx).Err(), "ping:")
+}
+
+// postgresMetadata 地址 / 库名（不含账号密码）与 sql.DB 连接池统计
+func (d *Data) postgresMetadata(c *conf.Bootstrap) func(context.Context) map[string]string {
+	addr, database := parsePostgresDSN(c.GetData().GetPostgres().GetDsn())
+	return func(context.Context) map[string]string {
+		md := map[string]string{}
+		if addr != "" {
+			md["addr"] = addr
+		}
+		if database != "" {
+			md["database"] = database
+		}
+
+		sqlDB, err := d.pg.DB()
+		if err != nil {
+			return md
+		}
+		poolMetadata(md, "", sqlDB)
+		return md
+	}
+}
+
+// sqliteMetadata 数据库文件路径与 sql.DB 连接池统计
+func (d *Data) sqliteMetadata(c *conf.Bootstrap) func(context.Context) map[string]string {
+	path := c.GetData().GetSqlite().GetPath()
+	return func(context.Context) map[string]string {
+		md := map[string]string{"path": path}
+		if sqlDB, err := d.pg.DB(); err == nil {
+			poolMetadata(md, "", sqlDB)
+		}
+		return md
+	}
+}
+
+// redisMetadata 部署模式、节点地址与连接池统计
+func (d *Data) redisMetadata(c *conf.Bootstrap) func(context.Context) map[string]string {
+	mode, _ := redisMode(c)
+	addrs := strings.Join(redisAddrs(c), ",")
+	return func(context.Context) map[string]string {
+		st := d.rdb.PoolStats()
+		return map[string]string{
+			"mode":             mode,
+			"addrs":            addrs,
+			"pool_total_conns": strconv.FormatUint(uint64(st.TotalConns), 10),
+			"pool_idle_conns":  strconv.FormatUint(uint64(st.IdleConns), 10),
+			"pool_stale_conns": strconv.FormatUint(uint64(st.StaleConns), 10),
+			"pool_hits":        strconv.FormatUint(uint64(st.Hits), 10),
+			"pool_misses":      strconv.FormatUint(uint64(st.Misses), 10),
+			"pool_timeouts":    strconv.FormatUint(uint64(st.Timeouts), 10),
+		}
+	}
+}
+
+// parsePostgresDSN 从 URL 或 key=value 形式的 DSN 中解析地址与库名
+func parsePostgresDSN(dsn string) (addr, database string) {
+	if u, err := url.Parse(dsn); err == nil && u.Host != "" {
+		return u.Host, strings.TrimPrefix(u.Path, "/")
+	}
+
+	var host, port string
+	for _, kv := range strings.Fields(dsn) {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			continue
+		}
+		switch k {
+		case "host":
+			host = v
+		case "port":
+			port = v
+		case "dbname":
+			database = v
+		}
+	}
+	if host != "" && port != "" {
+		addr = host + ":" + port
+	} else {
+		addr = host
+	}
+	return addr, database
+}
@@ -2,44 +2,157 @@ package data
 
 import (
 	"context"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/jeffinity/singularity/pgx"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
 
 	"github.com/jeffinity/app-layout/app/app_layout/internal/biz"
 )
 
-func NewHelloRepo(data *Data, logger log.Logger) biz.HelloRepo {
+func NewHelloRepo(data *Data, cm *CacheMetrics, logger log.Logger) biz.HelloRepo {
 	return &helloRepo{
 		data: data,
-		log:  log.NewHelper(log.With(logger, "module", "app_layout/helloRepo")),
+		cache: NewCache[biz.Hello](data, cm, "hello", logger,
+			WithCacheTTL(10*time.Minute),
+			WithNegativeCache(biz.ErrHelloNotFound, 30*time.Second),
+		),
+		log: log.NewHelper(log.With(logger, "module", "app_layout/helloRepo")),
 	}
 }
 
 type helloRepo struct {
-	data *Data
-	log  *log.Helper
+	data  *Data
+	cache *Cache[biz.Hello] // 按 ID 缓存
+	log   *log.Helper
 }
 
-func (h *helloRepo) Save(ctx context.Context) error {
-	//TODO implement me
-	panic("implement me")
+func (h *helloRepo) Save(ctx context.Context, hello *biz.Hello) (*biz.Hello, error) {
+	db, err := h.data.DB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := &Hello{Name: hello.Name}
+	if err := db.Create(m).Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+	// 清除该 ID 可能存在的负缓存
+	h.cache.Invalidate(ctx, helloCacheKey(m.ID))
+	return m.toBiz(), nil
 }
 
-func (h *helloRepo) Update(ctx context.Context) error {
-	//TODO implement me
-	panic("implement me")
+func (h *helloRepo) Update(ctx context.Context, hello *biz.Hello) (*biz.Hello, error) {
+	var m Hello
+	err := h.data.InTx(ctx, func(ctx context.Context) error {
+		db, err := h.data.DB(ctx)
+		if err != nil {
+			return err
+		}
+		res := db.Model(&Hello{}).Where("id = ?", hello.ID).Update("name", hello.Name)
+		if res.Error != nil {
+			return errors.WithStack(res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return biz.ErrHelloNotFound
+		}
+		return errors.WithStack(db.First(&m, hello.ID).Error)
+	})
+	if err != nil {
+		return nil, err
+	}
+	h.cache.Invalidate(ctx, helloCacheKey(hello.ID))
+	return m.toBiz(), nil
 }
 
-func (h *helloRepo) ListAll(ctx context.Context) error {
-	//TODO implement me
-	panic("implement me")
+func (h *helloRepo) FindByID(ctx context.Context, id int64) (*biz.Hello, error) {
+	return h.cache.Get(ctx, helloCacheKey(id), func(ctx context.Context) (*biz.Hello, error) {
+		db, err := h.data.DB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var m Hello
+		if err := db.First(&m, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, biz.ErrHelloNotFound
+			}
+			return nil, errors.WithStack(err)
+		}
+		return m.toBiz(), nil
+	})
+}
+
+func (h *helloRepo) Delete(ctx context.Context, id int64) error {
+	db, err := h.data.DB(ctx)
+	if err != nil {
+		return err
+	}
+	res := db.Delete(&Hello{}, id)
+	if res.Error != nil {
+		return errors.WithStack(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return biz.ErrHelloNotFound
+	}
+	h.cache.Invalidate(ctx, helloCacheKey(id))
+	return nil
+}
+
+func helloCacheKey(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+func (h *helloRepo) List(ctx context.Context, f biz.HelloFilter) ([]*biz.Hello, int64, error) {
+	db, err := h.data.DB(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	db = db.Model(&Hello{})
+	if f.NamePrefix != "" {
+		// SQLite 没有默认的转义字符，显式声明以与 Postgres 行为一致
+		db = db.Where(`name LIKE ? ESCAPE '\'`, escapeLike(f.NamePrefix)+"%")
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+	if total == 0 {
+		return nil, 0, nil
+	}
+
+	var ms []*Hello
+	if err := db.Order("id DESC").Offset(f.Offset).Limit(f.Limit).Find(&ms).Error; err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+	hellos := make([]*biz.Hello, 0, len(ms))
+	for _, m := range ms {
+		hellos = append(hellos, m.toBiz())
+	}
+	return hellos, total, nil
+}
+
+// escapeLike 转义 LIKE 通配符，使前缀按字面匹配
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
 }
 
 type Hello struct {
 	pgx.BaseModel
 
-	Name string `gorm:"column:name;type:varchar(64);comment:注册节点ID" json:"name"`
+	Name string `gorm:"column:name;type:varchar(64);not null;index;comment:名称" json:"name"`
 }
 
 func (*Hello) TableName() string { return "hellos" }
+
+func (m *Hello) toBiz() *biz.Hello {
+	return &biz.Hello{
+		ID:        m.ID,
+		Name:      m.Name,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
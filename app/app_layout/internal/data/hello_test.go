@@ -0,0 +1,130 @@
+package data_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/oklog/ulid/v2"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/biz"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/test"
+)
+
+// namePrefix 返回本测试独有的名称前缀，共用测试库时各测试的数据互不干扰
+func namePrefix() string {
+	return ulid.Make().String() + "-"
+}
+
+func assertHelloNotFound(t *testing.T, err error) {
+	t.Helper()
+	if !errors.Is(err, biz.ErrHelloNotFound) || kerrors.Code(err) != 404 {
+		t.Fatalf("got %v, want 404 HELLO_NOT_FOUND", err)
+	}
+}
+
+func TestHelloRepoCRUD(t *testing.T) {
+	r := test.InitTest()
+	repo, ctx := r.HelloRepo(), r.Context()
+	alice, bob := namePrefix()+"alice", namePrefix()+"bob"
+
+	saved, err := repo.Save(ctx, &biz.Hello{Name: alice})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.ID <= 0 || saved.Name != alice || saved.CreatedAt.IsZero() {
+		t.Fatalf("unexpected saved hello %+v", saved)
+	}
+
+	found, err := repo.FindByID(ctx, saved.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.ID != saved.ID || found.Name != alice {
+		t.Fatalf("found %+v, want %+v", found, saved)
+	}
+
+	updated, err := repo.Update(ctx, &biz.Hello{ID: saved.ID, Name: bob})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.ID != saved.ID || updated.Name != bob {
+		t.Fatalf("unexpected updated hello %+v", updated)
+	}
+	if found, err = repo.FindByID(ctx, saved.ID); err != nil || found.Name != bob {
+		t.Fatalf("found %+v, %v after update", found, err)
+	}
+
+	if err := repo.Delete(ctx, saved.ID); err != nil {
+		t.Fatal(err)
+	}
+	_, err = repo.FindByID(ctx, saved.ID)
+	assertHelloNotFound(t, err)
+}
+
+func TestHelloRepoNotFound(t *testing.T) {
+	r := test.InitTest()
+	repo, ctx := r.HelloRepo(), r.Context()
+	const id = math.MaxInt64
+
+	_, err := repo.FindByID(ctx, id)
+	assertHelloNotFound(t, err)
+	_, err = repo.Update(ctx, &biz.Hello{ID: id, Name: "bob"})
+	assertHelloNotFound(t, err)
+	assertHelloNotFound(t, repo.Delete(ctx, id))
+}
+
+func TestHelloRepoList(t *testing.T) {
+	r := test.InitTest()
+	repo, ctx := r.HelloRepo(), r.Context()
+	p := namePrefix()
+
+	names := []string{"a%b", "a_b", "axb", `a\b`, "ab", "b"}
+	ids := make(map[string]int64, len(names))
+	for _, name := range names {
+		h, err := repo.Save(ctx, &biz.Hello{Name: p + name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[name] = h.ID
+	}
+
+	tests := []struct {
+		prefix string
+		want   []string // 按 ID 倒序
+	}{
+		{prefix: "", want: []string{"b", "ab", `a\b`, "axb", "a_b", "a%b"}},
+		{prefix: "a", want: []string{"ab", `a\b`, "axb", "a_b", "a%b"}},
+		// 通配符按字面匹配
+		{prefix: "a%", want: []string{"a%b"}},
+		{prefix: "a_", want: []string{"a_b"}},
+		{prefix: `a\`, want: []string{`a\b`}},
+		{prefix: "c", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			hellos, total, err := repo.List(ctx, biz.HelloFilter{NamePrefix: p + tt.prefix, Limit: 100})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if total != int64(len(tt.want)) || len(hellos) != len(tt.want) {
+				t.Fatalf("got %d hellos, total %d, want %d", len(hellos), total, len(tt.want))
+			}
+			for i, h := range hellos {
+				if h.Name != p+tt.want[i] || h.ID != ids[tt.want[i]] {
+					t.Fatalf("hellos[%d] = %+v, want %q", i, h, tt.want[i])
+				}
+			}
+		})
+	}
+
+	// 分页：total 为满足条件的总数，与 offset / limit 无关
+	hellos, total, err := repo.List(ctx, biz.HelloFilter{NamePrefix: p + "a", Offset: 1, Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 5 || len(hellos) != 2 || hellos[0].Name != p+`a\b` || hellos[1].Name != p+"axb" {
+		t.Fatalf("got %d hellos %v, total %d", len(hellos), hellos, total)
+	}
+}
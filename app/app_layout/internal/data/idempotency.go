@@ -0,0 +1,110 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/biz"
+)
+
+const (
+	idempotencyKeyPrefix = "app_layout:idempotency:"
+	idempotencyInFlight  = "inflight:" // 处理中标记前缀，后接占用者 token
+)
+
+var (
+	// 仅当仍为 token 持有的处理中标记时写入结果
+	idempotencyCompleteScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+end
+return false`)
+
+	// 仅当仍为 token 持有的处理中标记时删除
+	idempotencyReleaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+func NewIdempotencyRepo(data *Data, logger log.Logger) biz.IdempotencyRepo {
+	return &idempotencyRepo{
+		data: data,
+		log:  log.NewHelper(log.With(logger, "module", "app_layout/idempotencyRepo")),
+	}
+}
+
+// idempotencyRepo 每个幂等键对应一个 Redis string：
+// - 处理中：inflight:<token>，带 lockTTL
+// - 已完成：IdempotencyRecord 的 JSON，带 ttl
+type idempotencyRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func (r *idempotencyRepo) Acquire(ctx context.Context, key, token string, lockTTL time.Duration) (biz.IdempotencyState, *biz.IdempotencyRecord, error) {
+	rdb, err := r.data.Redis()
+	if err != nil {
+		return 0, nil, err
+	}
+	rk := idempotencyKeyPrefix + key
+	ok, err := rdb.SetNX(ctx, rk, idempotencyInFlight+token, lockTTL).Result()
+	if err != nil {
+		return 0, nil, errors.WithStack(err)
+	}
+	if ok {
+		return biz.IdempotencyAcquired, nil, nil
+	}
+
+	val, err := rdb.Get(ctx, rk).Result()
+	if errors.Is(err, redis.Nil) {
+		// 恰好在 SETNX 与 GET 之间过期 / 被释放，视为处理中，由客户端稍后重试
+		return biz.IdempotencyInFlight, nil, nil
+	}
+	if err != nil {
+		return 0, nil, errors.WithStack(err)
+	}
+	if strings.HasPrefix(val, idempotencyInFlight) {
+		return biz.IdempotencyInFlight, nil, nil
+	}
+
+	var rec biz.IdempotencyRecord
+	if err := json.Unmarshal([]byte(val), &rec); err != nil {
+		return 0, nil, errors.WithMessagef(err, "decode idempotency record %s failed:", key)
+	}
+	return biz.IdempotencyCompleted, &rec, nil
+}
+
+func (r *idempotencyRepo) Complete(ctx context.Context, key, token string, rec *biz.IdempotencyRecord, ttl time.Duration) error {
+	rdb, err := r.data.Redis()
+	if err != nil {
+		return err
+	}
+	val, err := json.Marshal(rec)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	err = idempotencyCompleteScript.Run(ctx, rdb, []string{idempotencyKeyPrefix + key},
+		idempotencyInFlight+token, val, ttl.Milliseconds()).Err()
+	if errors.Is(err, redis.Nil) {
+		r.log.WithContext(ctx).Warnf("idempotency key %s lock lost before completion, reply not saved", key)
+		return nil
+	}
+	return errors.WithStack(err)
+}
+
+func (r *idempotencyRepo) Release(ctx context.Context, key, token string) error {
+	rdb, err := r.data.Redis()
+	if err != nil {
+		return err
+	}
+	err = idempotencyReleaseScript.Run(ctx, rdb, []string{idempotencyKeyPrefix + key}, idempotencyInFlight+token).Err()
+	return errors.WithStack(err)
+}
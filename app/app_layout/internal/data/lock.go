@@ -0,0 +1,191 @@
+package data
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/oklog/ulid/v2"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/biz"
+)
+
+const (
+	lockKeyPrefix = "app_layout:lock:"
+
+	lockMinTTL        = time.Second
+	lockRetryMin      = 50 * time.Millisecond
+	lockRetryMax      = time.Second
+	lockReleaseExpiry = 3 * time.Second
+)
+
+var (
+	// 获取锁成功时递增并返回 fencing token
+	lockAcquireScript = redis.NewScript(`
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return redis.call("INCR", KEYS[2])
+end
+return 0`)
+
+	// 仅当仍由 owner 持有时续约
+	lockRenewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+	// 仅当仍由 owner 持有时删除
+	lockReleaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+func NewLocker(data *Data, logger log.Logger) biz.Locker {
+	return &redisLocker{
+		data: data,
+		log:  log.NewHelper(log.With(logger, "module", "app_layout/locker")),
+	}
+}
+
+// redisLocker 每个锁对应两个 Redis key，以 hash tag 保证集群模式下位于同一 slot：
+// - app_layout:lock:{key}：持有者标识，带租约
+// - app_layout:lock:{key}:fence：fencing token 计数器，不过期
+type redisLocker struct {
+	data *Data
+	log  *log.Helper
+}
+
+func (l *redisLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (biz.Lock, error) {
+	rdb, err := l.data.Redis()
+	if err != nil {
+		return nil, err
+	}
+	if ttl < lockMinTTL {
+		return nil, errors.Errorf("lock ttl %s is less than %s", ttl, lockMinTTL)
+	}
+
+	keys := []string{lockKeyPrefix + "{" + key + "}", lockKeyPrefix + "{" + key + "}:fence"}
+	owner := ulid.Make().String()
+	start := time.Now()
+	token, err := lockAcquireScript.Run(ctx, rdb, keys, owner, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "acquire lock %s:", key)
+	}
+	if token == 0 {
+		return nil, biz.ErrLockHeld
+	}
+
+	lctx, cancel := context.WithCancelCause(ctx)
+	lk := &redisLock{
+		rdb:     rdb,
+		key:     keys[0],
+		name:    key,
+		owner:   owner,
+		token:   token,
+		ttl:     ttl,
+		ctx:     lctx,
+		cancel:  cancel,
+		expires: start.Add(ttl),
+		done:    make(chan struct{}),
+		log:     l.log,
+	}
+	go lk.renew()
+	return lk, nil
+}
+
+func (l *redisLocker) Lock(ctx context.Context, key string, ttl time.Duration) (biz.Lock, error) {
+	backoff := lockRetryMin
+	for {
+		lk, err := l.TryLock(ctx, key, ttl)
+		if !errors.Is(err, biz.ErrLockHeld) {
+			return lk, err
+		}
+
+		// 随机化等待，避免多个副本同时重试
+		t := time.NewTimer(backoff/2 + rand.N(backoff/2+1))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+		backoff = min(backoff*2, lockRetryMax)
+	}
+}
+
+type redisLock struct {
+	rdb   redis.UniversalClient
+	key   string
+	name  string
+	owner string
+	token int64
+	ttl   time.Duration
+
+	ctx     context.Context
+	cancel  context.CancelCauseFunc
+	expires time.Time // 最近一次成功续约对应的租约到期时间，仅 renew 访问
+	done    chan struct{}
+	once    sync.Once
+	log     *log.Helper
+}
+
+func (lk *redisLock) Context() context.Context { return lk.ctx }
+func (lk *redisLock) Token() int64             { return lk.token }
+
+// renew 每 ttl/3 续约一次；锁已不属于自己时立即取消，Redis 出错时持续重试直至租约到期再取消
+func (lk *redisLock) renew() {
+	defer close(lk.done)
+	ticker := time.NewTicker(lk.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-lk.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		start := time.Now()
+		rctx, cancel := context.WithDeadline(lk.ctx, lk.expires)
+		ok, err := lockRenewScript.Run(rctx, lk.rdb, []string{lk.key}, lk.owner, lk.ttl.Milliseconds()).Int64()
+		cancel()
+		switch {
+		case lk.ctx.Err() != nil:
+			return
+		case err == nil && ok == 0:
+			lk.log.Warnf("lock %s (token %d) lost: taken over or expired", lk.name, lk.token)
+			lk.cancel(biz.ErrLockLost)
+			return
+		case err == nil:
+			lk.expires = start.Add(lk.ttl)
+		case !time.Now().Before(lk.expires):
+			lk.log.Errorf("lock %s (token %d) lost: renew failed until lease expired: %v", lk.name, lk.token, err)
+			lk.cancel(biz.ErrLockLost)
+			return
+		default:
+			lk.log.Warnf("renew lock %s (token %d) failed, will retry: %v", lk.name, lk.token, err)
+		}
+	}
+}
+
+func (lk *redisLock) Unlock(ctx context.Context) error {
+	var err error
+	lk.once.Do(func() {
+		lk.cancel(context.Canceled)
+		<-lk.done
+
+		ctx, cancel := context.WithTimeout(ctx, lockReleaseExpiry)
+		defer cancel()
+		err = errors.WithMessagef(
+			lockReleaseScript.Run(ctx, lk.rdb, []string{lk.key}, lk.owner).Err(),
+			"release lock %s:", lk.name,
+		)
+	})
+	return err
+}
@@ -0,0 +1,210 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/biz"
+)
+
+func lockKey(key string) string {
+	return lockKeyPrefix + "{" + key + "}"
+}
+
+// waitLost 等待租约丢失，返回 Context 的取消原因
+func waitLost(t *testing.T, lk biz.Lock, timeout time.Duration) error {
+	t.Helper()
+	select {
+	case <-lk.Context().Done():
+		return context.Cause(lk.Context())
+	case <-time.After(timeout):
+		t.Fatal("lock context is not canceled")
+		return nil
+	}
+}
+
+func TestLockFencingToken(t *testing.T) {
+	d, _ := newTestData(t)
+	locker := NewLocker(d, log.DefaultLogger)
+	ctx := context.Background()
+
+	var last int64
+	for range 3 {
+		lk, err := locker.TryLock(ctx, "job", time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lk.Token() <= last {
+			t.Fatalf("token %d is not greater than %d", lk.Token(), last)
+		}
+		last = lk.Token()
+
+		if _, err := locker.TryLock(ctx, "job", time.Second); !errors.Is(err, biz.ErrLockHeld) {
+			t.Fatalf("got %v, want %v", err, biz.ErrLockHeld)
+		}
+		if err := lk.Unlock(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if err := lk.Unlock(ctx); err != nil {
+			t.Fatalf("repeated unlock: %v", err)
+		}
+		if !errors.Is(context.Cause(lk.Context()), context.Canceled) {
+			t.Fatalf("got cause %v after unlock, want %v", context.Cause(lk.Context()), context.Canceled)
+		}
+	}
+
+	// 不同 key 的 token 相互独立
+	lk, err := locker.TryLock(ctx, "other", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = lk.Unlock(ctx) }()
+	if lk.Token() != 1 {
+		t.Fatalf("got token %d, want 1", lk.Token())
+	}
+}
+
+func TestLockRenew(t *testing.T) {
+	d, mr := newTestData(t)
+	locker := NewLocker(d, log.DefaultLogger)
+	ctx := context.Background()
+
+	lk, err := locker.TryLock(ctx, "job", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = lk.Unlock(ctx) }()
+
+	// 多次临近过期后由续约恢复租约
+	for range 3 {
+		mr.FastForward(800 * time.Millisecond)
+		time.Sleep(400 * time.Millisecond)
+		if ttl := mr.TTL(lockKey("job")); ttl <= 200*time.Millisecond {
+			t.Fatalf("lease is not renewed, ttl %s", ttl)
+		}
+	}
+	if lk.Context().Err() != nil {
+		t.Fatalf("lock lost while renewing: %v", context.Cause(lk.Context()))
+	}
+}
+
+func TestLockLostOnTakeover(t *testing.T) {
+	d, mr := newTestData(t)
+	locker := NewLocker(d, log.DefaultLogger)
+	ctx := context.Background()
+
+	lk, err := locker.TryLock(ctx, "job", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mr.Set(lockKey("job"), "other"); err != nil {
+		t.Fatal(err)
+	}
+	if cause := waitLost(t, lk, time.Second); !errors.Is(cause, biz.ErrLockLost) {
+		t.Fatalf("got cause %v, want %v", cause, biz.ErrLockLost)
+	}
+
+	// 锁已不属于自己，Unlock 不删除新持有者的 key
+	if err := lk.Unlock(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := mr.Get(lockKey("job")); err != nil || v != "other" {
+		t.Fatalf("got %q, %v, want the new owner to keep the lock", v, err)
+	}
+}
+
+func TestLockLostOnRedisFailure(t *testing.T) {
+	d, mr := newTestData(t)
+	locker := NewLocker(d, log.DefaultLogger)
+
+	lk, err := locker.TryLock(context.Background(), "job", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	mr.Close()
+
+	// Redis 不可用时持续重试，直至租约到期才取消
+	if cause := waitLost(t, lk, 3*time.Second); !errors.Is(cause, biz.ErrLockLost) {
+		t.Fatalf("got cause %v, want %v", cause, biz.ErrLockLost)
+	}
+	if elapsed := time.Since(start); elapsed < 500*time.Millisecond {
+		t.Fatalf("lock lost after %s, before the lease expired", elapsed)
+	}
+}
+
+func TestUnlockAfterExpiry(t *testing.T) {
+	d, mr := newTestData(t)
+	locker := NewLocker(d, log.DefaultLogger)
+	ctx := context.Background()
+
+	old, err := locker.TryLock(ctx, "job", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 租约过期后被新持有者获取
+	mr.FastForward(2 * time.Second)
+	cur, err := locker.TryLock(ctx, "job", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = cur.Unlock(ctx) }()
+	if cur.Token() <= old.Token() {
+		t.Fatalf("token %d is not greater than %d", cur.Token(), old.Token())
+	}
+	if cause := waitLost(t, old, time.Second); !errors.Is(cause, biz.ErrLockLost) {
+		t.Fatalf("got cause %v, want %v", cause, biz.ErrLockLost)
+	}
+
+	if err := old.Unlock(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !mr.Exists(lockKey("job")) {
+		t.Fatal("expired owner released the lock of the new owner")
+	}
+	if cur.Context().Err() != nil {
+		t.Fatalf("new owner lost the lock: %v", context.Cause(cur.Context()))
+	}
+}
+
+func TestLockWait(t *testing.T) {
+	d, _ := newTestData(t)
+	locker := NewLocker(d, log.DefaultLogger)
+	ctx := context.Background()
+
+	held, err := locker.TryLock(ctx, "job", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.AfterFunc(100*time.Millisecond, func() { _ = held.Unlock(ctx) })
+
+	lk, err := locker.Lock(ctx, "job", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = lk.Unlock(ctx) }()
+	if lk.Token() != held.Token()+1 {
+		t.Fatalf("got token %d, want %d", lk.Token(), held.Token()+1)
+	}
+
+	// 等待期间 ctx 结束时返回
+	wctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if _, err := locker.Lock(wctx, "job", time.Second); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestLockWithoutRedis(t *testing.T) {
+	d, _ := newTestData(t)
+	d.rdb = nil
+	locker := NewLocker(d, log.DefaultLogger)
+
+	if _, err := locker.TryLock(context.Background(), "job", time.Second); !errors.Is(err, ErrRedisNotConfigured) {
+		t.Fatalf("got %v, want %v", err, ErrRedisNotConfigured)
+	}
+}
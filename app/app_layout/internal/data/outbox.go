@@ -0,0 +1,134 @@
+package data
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/jeffinity/singularity/pgx"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/biz"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+)
+
+const (
+	defaultOutboxStreamPrefix = "app_layout:events:"
+	defaultOutboxStreamMaxLen = 100000
+)
+
+// OutboxEvent outbox 表，待投递事件 published_at 与 dead_at 均为空；
+// dead 事件保留在表中供排查，修复后将 attempts / next_attempt_at / dead_at 置空即可重新投递
+type OutboxEvent struct {
+	pgx.BaseModel
+
+	AggregateType string     `gorm:"column:aggregate_type;type:varchar(64);not null;index:idx_outbox_events_aggregate,priority:1;comment:聚合类型" json:"aggregate_type"`
+	AggregateID   string     `gorm:"column:aggregate_id;type:varchar(128);not null;index:idx_outbox_events_aggregate,priority:2;comment:聚合 ID" json:"aggregate_id"`
+	EventType     string     `gorm:"column:event_type;type:varchar(128);not null;comment:事件类型" json:"event_type"`
+	Payload       []byte     `gorm:"column:payload;comment:事件内容" json:"payload"`
+	Attempts      int32      `gorm:"column:attempts;not null;default:0;comment:投递失败次数" json:"attempts"`
+	NextAttemptAt *time.Time `gorm:"column:next_attempt_at;comment:下次重试时间" json:"next_attempt_at"`
+	LastError     string     `gorm:"column:last_error;type:text;comment:最近一次投递错误" json:"last_error"`
+	PublishedAt   *time.Time `gorm:"column:published_at;index;comment:投递时间" json:"published_at"`
+	DeadAt        *time.Time `gorm:"column:dead_at;index;comment:达到最大重试次数放弃投递的时间" json:"dead_at"`
+}
+
+func (*OutboxEvent) TableName() string { return "outbox_events" }
+
+func NewOutbox(data *Data) biz.Outbox {
+	return &outboxRepo{data: data}
+}
+
+type outboxRepo struct {
+	data *Data
+}
+
+// Append 写入 outbox 表，ctx 处于 InTx 事务中时随事务提交；提交后通知 relay 立即投递
+func (r *outboxRepo) Append(ctx context.Context, events ...*biz.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	db, err := r.data.DB(ctx)
+	if err != nil {
+		return err
+	}
+	ms := make([]*OutboxEvent, 0, len(events))
+	for _, e := range events {
+		ms = append(ms, &OutboxEvent{
+			AggregateType: e.AggregateType,
+			AggregateID:   e.AggregateID,
+			EventType:     e.Type,
+			Payload:       e.Payload,
+		})
+	}
+	if err := db.Create(&ms).Error; err != nil {
+		return errors.WithStack(err)
+	}
+	r.data.AfterCommit(ctx, func(context.Context) { r.data.notifyOutbox() })
+	return nil
+}
+
+// notifyOutbox 唤醒 relay，已有未处理的通知时直接返回
+func (d *Data) notifyOutbox() {
+	select {
+	case d.outboxNotify <- struct{}{}:
+	default:
+	}
+}
+
+// EventSink outbox 事件的投递目标，默认为 Redis Streams；接入其他消息系统时替换 ProviderSet 中的 NewRedisStreamSink
+type EventSink interface {
+	// Ready 检查投递目标的依赖是否已配置，返回错误时 relay 不启动
+	Ready() error
+	// Send 投递单个事件，返回 nil 表示目标系统已接收；重试可能导致重复投递，消费方需按事件 ID 幂等
+	Send(ctx context.Context, e *OutboxEvent) error
+}
+
+// NewRedisStreamSink 按聚合类型写入 <stream_prefix><aggregate_type>，stream 按 stream_max_len 近似截断
+func NewRedisStreamSink(c *conf.Bootstrap, data *Data) EventSink {
+	oc := c.GetData().GetOutbox()
+	s := &redisStreamSink{
+		data:   data,
+		prefix: oc.GetStreamPrefix(),
+		maxLen: oc.GetStreamMaxLen(),
+	}
+	if s.prefix == "" {
+		s.prefix = defaultOutboxStreamPrefix
+	}
+	if s.maxLen <= 0 {
+		s.maxLen = defaultOutboxStreamMaxLen
+	}
+	return s
+}
+
+type redisStreamSink struct {
+	data   *Data
+	prefix string
+	maxLen int64
+}
+
+func (s *redisStreamSink) Ready() error {
+	_, err := s.data.Redis()
+	return err
+}
+
+func (s *redisStreamSink) Send(ctx context.Context, e *OutboxEvent) error {
+	rdb, err := s.data.Redis()
+	if err != nil {
+		return err
+	}
+	return errors.WithStack(rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: s.prefix + e.AggregateType,
+		MaxLen: s.maxLen,
+		Approx: true,
+		Values: map[string]any{
+			"id":             strconv.FormatInt(e.ID, 10),
+			"aggregate_type": e.AggregateType,
+			"aggregate_id":   e.AggregateID,
+			"type":           e.EventType,
+			"payload":        e.Payload,
+			"created_at":     e.CreatedAt.UTC().Format(time.RFC3339Nano),
+		},
+	}).Err())
+}
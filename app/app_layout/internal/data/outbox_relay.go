@@ -0,0 +1,373 @@
+package data
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/biz"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/metricx"
+)
+
+const (
+	outboxRelayLock     = "outbox-relay"
+	outboxRelayLockTTL  = 30 * time.Second
+	outboxCleanupPeriod = 10 * time.Minute
+
+	defaultOutboxPollInterval    = time.Second
+	defaultOutboxBatchSize       = 100
+	defaultOutboxRetryBackoff    = time.Second
+	defaultOutboxMaxRetryBackoff = time.Minute
+	defaultOutboxRetention       = 7 * 24 * time.Hour
+	defaultOutboxMaxAttempts     = 16
+)
+
+// outboxNotBlockedSQL 同一聚合中不存在更早的、仍在等待重试的事件；dead 事件不阻塞后续事件
+const outboxNotBlockedSQL = `NOT EXISTS (
+	SELECT 1 FROM outbox_events AS prev
+	WHERE prev.aggregate_type = outbox_events.aggregate_type AND prev.aggregate_id = outbox_events.aggregate_id
+		AND prev.id < outbox_events.id AND prev.published_at IS NULL AND prev.dead_at IS NULL
+		AND prev.next_attempt_at > ?
+)`
+
+// OutboxRelay 将 outbox 表中的事件投递到 EventSink，随 kratos 应用启停（实现 transport.Server）：
+// - 多副本通过分布式锁选出唯一的投递者，锁丢失后停止投递并重新竞争
+// - 按 ID 顺序投递；事件失败后按指数退避重试，期间同一聚合的后续事件不投递，保证聚合内有序
+// - 失败达到 max_attempts 次的事件标记为 dead，不再重试，也不再阻塞同一聚合的后续事件
+// - 所有副本按 poll_interval 更新 outbox_lag_seconds（最早待投递事件的等待时长）与 outbox_dead_events
+// - EventSink 的依赖（如 Redis）未配置时不启动；outbox 表尚未迁移时按退避间隔等待，只记录一次日志
+type OutboxRelay struct {
+	data    *Data
+	sink    EventSink
+	locker  biz.Locker
+	metrics *outboxMetrics
+
+	disabled        bool
+	pollInterval    time.Duration
+	batchSize       int
+	retryBackoff    time.Duration
+	maxRetryBackoff time.Duration
+	maxAttempts     int32
+	retention       time.Duration
+
+	lastCleanup time.Time
+	lagFailing  bool // 上一次查询 lag 失败，仅 watchLag 访问
+	stop        chan struct{}
+	once        sync.Once
+	log         *log.Helper
+}
+
+type outboxMetrics struct {
+	lag    prometheus.Gauge
+	dead   prometheus.Gauge
+	events *prometheus.CounterVec
+}
+
+func NewOutboxRelay(c *conf.Bootstrap, data *Data, sink EventSink, locker biz.Locker, mr *metricx.Registry, logger log.Logger) (*OutboxRelay, error) {
+	oc := c.GetData().GetOutbox()
+	r := &OutboxRelay{
+		data:            data,
+		sink:            sink,
+		locker:          locker,
+		disabled:        oc.GetDisabled(),
+		pollInterval:    defaultOutboxPollInterval,
+		batchSize:       defaultOutboxBatchSize,
+		retryBackoff:    defaultOutboxRetryBackoff,
+		maxRetryBackoff: defaultOutboxMaxRetryBackoff,
+		maxAttempts:     defaultOutboxMaxAttempts,
+		retention:       defaultOutboxRetention,
+		stop:            make(chan struct{}),
+		log:             log.NewHelper(log.With(logger, "module", "app_layout/outboxRelay")),
+		metrics: &outboxMetrics{
+			lag: prometheus.NewGauge(prometheus.GaugeOpts{
+				Namespace: mr.Namespace(),
+				Name:      "outbox_lag_seconds",
+				Help:      "Age of the oldest unpublished outbox event, 0 when none.",
+			}),
+			dead: prometheus.NewGauge(prometheus.GaugeOpts{
+				Namespace: mr.Namespace(),
+				Name:      "outbox_dead_events",
+				Help:      "Number of outbox events given up after max_attempts failed deliveries.",
+			}),
+			events: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: mr.Namespace(),
+				Name:      "outbox_events_total",
+				Help:      "Number of outbox event deliveries by result: published, failed (will retry) or dead (given up).",
+			}, []string{"result"}),
+		},
+	}
+	if oc.GetPollInterval() != nil {
+		r.pollInterval = oc.GetPollInterval().AsDuration()
+	}
+	if oc.GetBatchSize() > 0 {
+		r.batchSize = int(oc.GetBatchSize())
+	}
+	if oc.GetRetryBackoff() != nil {
+		r.retryBackoff = oc.GetRetryBackoff().AsDuration()
+	}
+	if oc.GetMaxRetryBackoff() != nil {
+		r.maxRetryBackoff = oc.GetMaxRetryBackoff().AsDuration()
+	}
+	if oc.GetMaxAttempts() > 0 {
+		r.maxAttempts = oc.GetMaxAttempts()
+	}
+	if oc.GetRetention() != nil {
+		r.retention = oc.GetRetention().AsDuration()
+	}
+	if err := mr.Register(r.metrics.lag, r.metrics.dead, r.metrics.events); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *OutboxRelay) Start(ctx context.Context) error {
+	if r.disabled || r.data.pg == nil {
+		r.log.Info("outbox relay disabled")
+		return nil
+	}
+	if err := r.sink.Ready(); err != nil {
+		r.log.Warnf("outbox relay disabled, events are kept in outbox_events: %v", err)
+		return nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-r.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if !r.waitTable(ctx) {
+		return nil
+	}
+	go r.watchLag(ctx)
+	r.log.Infof("outbox relay started, poll interval: %s, batch size: %d", r.pollInterval, r.batchSize)
+	for ctx.Err() == nil {
+		lk, err := r.locker.Lock(ctx, outboxRelayLock, outboxRelayLockTTL)
+		switch {
+		case errors.Is(err, ErrRedisNotConfigured):
+			r.log.Warn("redis is not configured, outbox relay runs without leader election, deploy a single replica")
+			r.run(ctx)
+			return nil
+		case err != nil:
+			if ctx.Err() == nil {
+				r.log.Errorf("acquire outbox relay lock failed: %v", err)
+				r.sleep(ctx, r.pollInterval)
+			}
+			continue
+		}
+
+		r.log.Infof("outbox relay became leader, fencing token: %d", lk.Token())
+		r.run(lk.Context())
+		if err := lk.Unlock(context.WithoutCancel(ctx)); err != nil {
+			r.log.Warnf("release outbox relay lock failed: %v", err)
+		}
+	}
+	return nil
+}
+
+func (r *OutboxRelay) Stop(_ context.Context) error {
+	r.once.Do(func() { close(r.stop) })
+	return nil
+}
+
+// waitTable 等待 outbox 表就绪，ctx 结束时返回 false；
+// 表不存在（未执行 migrate）时按退避间隔重新检查，只在开始等待与就绪时各记录一次日志
+func (r *OutboxRelay) waitTable(ctx context.Context) bool {
+	for i := int32(0); ; i++ {
+		db, err := r.data.DB(biz.WithReadPrimary(ctx))
+		if err != nil {
+			return false
+		}
+		if db.Migrator().HasTable(&OutboxEvent{}) {
+			if i > 0 {
+				r.log.Info("outbox_events table is ready")
+			}
+			return true
+		}
+		if i == 0 {
+			r.log.Warn("outbox_events table not found (not migrated or database unavailable), outbox relay waits for it")
+		}
+		r.sleep(ctx, r.backoff(i))
+		if ctx.Err() != nil {
+			return false
+		}
+	}
+}
+
+// run 持续投递直至 ctx 结束；批次已满时立即处理下一批，否则等待新事件通知或 poll_interval；
+// 连续出错（如数据库不可用）时按退避间隔重试，期间忽略新事件通知
+func (r *OutboxRelay) run(ctx context.Context) {
+	var failures int32
+	for {
+		full, err := r.relayOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		wait, notify := r.pollInterval, r.data.outboxNotify
+		switch {
+		case err != nil:
+			wait, notify = max(r.pollInterval, r.backoff(failures)), nil
+			failures++
+			r.log.Errorf("relay outbox events failed %d times in a row, retry in %s: %v", failures, wait, err)
+		case full:
+			failures = 0
+			continue
+		default:
+			failures = 0
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+		case <-notify:
+		case <-t.C:
+		}
+		t.Stop()
+	}
+}
+
+// relayOnce 处理一批待投递事件，返回批次是否已满；
+// 只读取已到重试时间、且所在聚合没有更早事件等待重试的事件，避免被阻塞的事件占满批次
+func (r *OutboxRelay) relayOnce(ctx context.Context) (bool, error) {
+	db, err := r.data.DB(biz.WithReadPrimary(ctx))
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	var events []*OutboxEvent
+	if err := db.Where("published_at IS NULL AND dead_at IS NULL").
+		Where("(next_attempt_at IS NULL OR next_attempt_at <= ?)", now).
+		Where(outboxNotBlockedSQL, now).
+		Order("id").Limit(r.batchSize).Find(&events).Error; err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	// 本轮投递失败的聚合，其后续事件本轮跳过
+	blocked := make(map[[2]string]struct{})
+	for _, e := range events {
+		if ctx.Err() != nil {
+			return false, nil
+		}
+		agg := [2]string{e.AggregateType, e.AggregateID}
+		if _, ok := blocked[agg]; ok {
+			continue
+		}
+		now := time.Now()
+		if err := r.sink.Send(ctx, e); err != nil {
+			if ctx.Err() != nil {
+				// 锁丢失或停止，不计为投递失败
+				return false, nil
+			}
+			attempts := e.Attempts + 1
+			updates := map[string]any{"attempts": attempts, "last_error": err.Error()}
+			if attempts >= r.maxAttempts {
+				// 放弃投递，同一聚合的后续事件继续投递
+				r.metrics.events.WithLabelValues("dead").Inc()
+				r.log.Errorf("publish outbox event %d (%s) failed %d times, marked as dead: %v",
+					e.ID, e.EventType, attempts, err)
+				updates["dead_at"] = now
+			} else {
+				blocked[agg] = struct{}{}
+				r.metrics.events.WithLabelValues("failed").Inc()
+				next := now.Add(r.backoff(e.Attempts))
+				r.log.Warnf("publish outbox event %d (%s) failed, attempt %d, retry at %s: %v",
+					e.ID, e.EventType, attempts, next.Format(time.RFC3339), err)
+				updates["next_attempt_at"] = next
+			}
+			if err := db.Model(e).Updates(updates).Error; err != nil {
+				return false, errors.WithStack(err)
+			}
+			continue
+		}
+		r.metrics.events.WithLabelValues("published").Inc()
+		if err := db.Model(e).Update("published_at", now).Error; err != nil {
+			// 已投递但未标记，下一轮会重复投递，由消费方幂等处理
+			return false, errors.WithStack(err)
+		}
+	}
+
+	if time.Since(r.lastCleanup) > outboxCleanupPeriod {
+		r.lastCleanup = time.Now()
+		res := db.Where("published_at < ?", time.Now().Add(-r.retention)).Delete(&OutboxEvent{})
+		if res.Error != nil {
+			r.log.Warnf("clean up published outbox events failed: %v", res.Error)
+		} else if res.RowsAffected > 0 {
+			r.log.Infof("cleaned up %d published outbox events", res.RowsAffected)
+		}
+	}
+	return len(events) == r.batchSize && len(blocked) == 0, nil
+}
+
+// backoff 第 attempts+1 次失败后的重试间隔
+func (r *OutboxRelay) backoff(attempts int32) time.Duration {
+	d := r.retryBackoff
+	for i := int32(0); i < attempts && d < r.maxRetryBackoff; i++ {
+		d *= 2
+	}
+	return min(d, r.maxRetryBackoff)
+}
+
+// watchLag 按 poll_interval 更新 outbox_lag_seconds 与 outbox_dead_events，与是否为投递者无关
+func (r *OutboxRelay) watchLag(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+	for {
+		r.updateLag(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *OutboxRelay) updateLag(ctx context.Context) {
+	db, err := r.data.DB(biz.WithReadPrimary(ctx))
+	if err != nil {
+		return
+	}
+	var (
+		oldest OutboxEvent
+		dead   int64
+	)
+	err = db.Select("created_at").Where("published_at IS NULL AND dead_at IS NULL").Order("id").Limit(1).Find(&oldest).Error
+	if err == nil {
+		err = db.Model(&OutboxEvent{}).Where("dead_at IS NOT NULL").Count(&dead).Error
+	}
+	if err != nil {
+		// 持续失败时只记录首次，避免每个周期重复记录
+		if ctx.Err() == nil && !r.lagFailing {
+			r.lagFailing = true
+			r.log.Warnf("query outbox lag failed: %v", err)
+		}
+		return
+	}
+	if r.lagFailing {
+		r.lagFailing = false
+		r.log.Info("query outbox lag recovered")
+	}
+
+	r.metrics.dead.Set(float64(dead))
+	if oldest.CreatedAt.IsZero() {
+		r.metrics.lag.Set(0)
+	} else {
+		r.metrics.lag.Set(time.Since(oldest.CreatedAt).Seconds())
+	}
+}
+
+func (r *OutboxRelay) sleep(ctx context.Context, d time.Duration) {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+	case <-t.C:
+	}
+}
@@ -0,0 +1,208 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+)
+
+// fakeSink 记录投递成功的事件 ID，failing 中的事件 ID 投递失败
+type fakeSink struct {
+	mu      sync.Mutex
+	failing map[int64]bool
+	sent    []int64
+}
+
+func (s *fakeSink) Ready() error { return nil }
+
+func (s *fakeSink) Send(_ context.Context, e *OutboxEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failing[e.ID] {
+		return errors.New("sink unavailable")
+	}
+	s.sent = append(s.sent, e.ID)
+	return nil
+}
+
+func (s *fakeSink) setFailing(id int64, failing bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failing[id] = failing
+}
+
+func (s *fakeSink) sentIDs() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.sent)
+}
+
+func newTestRelay(t *testing.T, d *Data, sink EventSink, oc *conf.Outbox) *OutboxRelay {
+	t.Helper()
+	c := &conf.Bootstrap{Data: &conf.Data{Outbox: oc}}
+	r, err := NewOutboxRelay(c, d, sink, NewLocker(d, log.DefaultLogger), newTestMetrics(t), log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// appendEvents 依次写入事件，返回事件 ID
+func appendEvents(t *testing.T, d *Data, aggregateIDs ...string) []int64 {
+	t.Helper()
+	ids := make([]int64, 0, len(aggregateIDs))
+	for _, aggID := range aggregateIDs {
+		e := &OutboxEvent{AggregateType: "hello", AggregateID: aggID, EventType: "hello.updated"}
+		if err := d.pg.Create(e).Error; err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func getEvent(t *testing.T, d *Data, id int64) *OutboxEvent {
+	t.Helper()
+	var e OutboxEvent
+	if err := d.pg.First(&e, id).Error; err != nil {
+		t.Fatal(err)
+	}
+	return &e
+}
+
+func relayOnce(t *testing.T, r *OutboxRelay) {
+	t.Helper()
+	if _, err := r.relayOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOutboxRelaySkipsBlockedAggregate(t *testing.T) {
+	d, _ := newTestData(t)
+	sink := &fakeSink{failing: map[int64]bool{}}
+	// 每批一个事件：被阻塞的事件若仍被读出，会占满批次导致其他聚合无法投递
+	r := newTestRelay(t, d, sink, &conf.Outbox{BatchSize: 1})
+	ids := appendEvents(t, d, "a", "a", "b")
+	sink.setFailing(ids[0], true)
+
+	relayOnce(t, r)
+	first := getEvent(t, d, ids[0])
+	if first.Attempts != 1 || first.NextAttemptAt == nil || !first.NextAttemptAt.After(time.Now()) {
+		t.Fatalf("failed event should wait for retry, got attempts %d, next attempt at %v", first.Attempts, first.NextAttemptAt)
+	}
+
+	// 等待重试期间，聚合 a 的事件均不读取，聚合 b 正常投递
+	relayOnce(t, r)
+	relayOnce(t, r)
+	if got, want := sink.sentIDs(), []int64{ids[2]}; !slices.Equal(got, want) {
+		t.Fatalf("sent %v, want %v", got, want)
+	}
+	if e := getEvent(t, d, ids[0]); e.Attempts != 1 {
+		t.Fatalf("event retried before next_attempt_at, attempts %d", e.Attempts)
+	}
+
+	// 到达重试时间后按顺序投递聚合 a
+	sink.setFailing(ids[0], false)
+	if err := d.pg.Model(&OutboxEvent{}).Where("id = ?", ids[0]).Update("next_attempt_at", time.Now().Add(-time.Second)).Error; err != nil {
+		t.Fatal(err)
+	}
+	relayOnce(t, r)
+	relayOnce(t, r)
+	if got, want := sink.sentIDs(), []int64{ids[2], ids[0], ids[1]}; !slices.Equal(got, want) {
+		t.Fatalf("sent %v, want %v", got, want)
+	}
+}
+
+func TestOutboxRelayMarksDead(t *testing.T) {
+	d, _ := newTestData(t)
+	sink := &fakeSink{failing: map[int64]bool{}}
+	r := newTestRelay(t, d, sink, &conf.Outbox{
+		MaxAttempts:     2,
+		RetryBackoff:    durationpb.New(time.Millisecond),
+		MaxRetryBackoff: durationpb.New(time.Millisecond),
+	})
+	ids := appendEvents(t, d, "a", "a")
+	sink.setFailing(ids[0], true)
+
+	relayOnce(t, r)
+	if e := getEvent(t, d, ids[0]); e.DeadAt != nil {
+		t.Fatal("event marked as dead before max_attempts")
+	}
+	time.Sleep(5 * time.Millisecond)
+	relayOnce(t, r)
+	if e := getEvent(t, d, ids[0]); e.DeadAt == nil || e.Attempts != 2 || e.LastError == "" {
+		t.Fatalf("event should be dead after 2 attempts, got attempts %d, dead at %v", e.Attempts, e.DeadAt)
+	}
+
+	// dead 事件不再重试，也不阻塞同一聚合的后续事件
+	relayOnce(t, r)
+	if got, want := sink.sentIDs(), []int64{ids[1]}; !slices.Equal(got, want) {
+		t.Fatalf("sent %v, want %v", got, want)
+	}
+	if got := testutil.ToFloat64(r.metrics.events.WithLabelValues("dead")); got != 1 {
+		t.Fatalf("dead counter %v, want 1", got)
+	}
+	r.updateLag(context.Background())
+	if got := testutil.ToFloat64(r.metrics.dead); got != 1 {
+		t.Fatalf("dead gauge %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(r.metrics.lag); got != 0 {
+		t.Fatalf("lag %v, want 0", got)
+	}
+}
+
+func TestOutboxRelayDisabledWithoutRedis(t *testing.T) {
+	d, _ := newTestData(t)
+	d.rdb = nil
+	c := &conf.Bootstrap{}
+	r := newTestRelay(t, d, NewRedisStreamSink(c, d), nil)
+	appendEvents(t, d, "a")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := r.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("relay should not start without redis")
+	}
+	var e OutboxEvent
+	if err := d.pg.First(&e).Error; err != nil {
+		t.Fatal(err)
+	}
+	if e.Attempts != 0 {
+		t.Fatalf("event attempted %d times without redis", e.Attempts)
+	}
+}
+
+func TestOutboxRelayWaitsForTable(t *testing.T) {
+	d, _ := newTestData(t)
+	if err := d.pg.Migrator().DropTable(&OutboxEvent{}); err != nil {
+		t.Fatal(err)
+	}
+	r := newTestRelay(t, d, &fakeSink{failing: map[int64]bool{}}, &conf.Outbox{
+		RetryBackoff: durationpb.New(10 * time.Millisecond),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if r.waitTable(ctx) {
+		t.Fatal("waitTable should not return true before the table exists")
+	}
+
+	if err := d.pg.AutoMigrate(&OutboxEvent{}); err != nil {
+		t.Fatal(err)
+	}
+	if !r.waitTable(context.Background()) {
+		t.Fatal("waitTable should return true once the table exists")
+	}
+}
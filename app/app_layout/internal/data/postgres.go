@@ -0,0 +1,123 @@
+package data
+
+import (
+	"database/sql"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/jeffinity/singularity/pgx"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/metricx"
+)
+
+// NewDB 按配置打开 Postgres（data.postgres.dsn）或内嵌 SQLite（data.sqlite），均未配置时返回 nil
+func NewDB(c *conf.Bootstrap, replicas *Replicas, logger log.Logger) (*gorm.DB, error) {
+	dc := c.GetData()
+	switch {
+	case dc.GetPostgres().GetDsn() != "" && dc.GetSqlite() != nil:
+		return nil, errors.New("data.postgres and data.sqlite are mutually exclusive")
+	case dc.GetSqlite() != nil:
+		return openSqlite(c, logger)
+	case dc.GetPostgres().GetDsn() == "":
+		log.NewHelper(log.With(logger, "module", "app_layout/data")).Info("database is not configured, skipped")
+		return nil, nil
+	}
+	db, err := openPostgres(c, c.GetData().GetPostgres().GetDsn(), logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := replicas.registerResolver(db); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// openPostgres 打开连接并按 conf.Postgres 覆盖 pgx.NewPostgres 的默认连接池参数，主库与副本共用
+func openPostgres(c *conf.Bootstrap, dsn string, logger log.Logger) (*gorm.DB, error) {
+	pc := c.GetData().GetPostgres()
+	if st := pc.GetStatementTimeout(); st != nil {
+		var err error
+		if dsn, err = withStatementTimeout(dsn, st.AsDuration().Milliseconds()); err != nil {
+			return nil, err
+		}
+	}
+
+	db, err := pgx.NewPostgres(c.GetLog().GetLevel(), dsn, logger)
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, errors.WithMessage(err, "gorm get sql db failed:")
+	}
+	if n := pc.GetMaxOpenConns(); n > 0 {
+		sqlDB.SetMaxOpenConns(int(n))
+	}
+	if n := pc.GetMaxIdleConns(); n > 0 {
+		sqlDB.SetMaxIdleConns(int(n))
+	}
+	if d := pc.GetConnMaxLifetime(); d != nil {
+		sqlDB.SetConnMaxLifetime(d.AsDuration())
+	}
+	if d := pc.GetConnMaxIdleTime(); d != nil {
+		sqlDB.SetConnMaxIdleTime(d.AsDuration())
+	}
+	return db, nil
+}
+
+// withStatementTimeout 将 statement_timeout（毫秒）作为连接参数追加到 URL 或 key=value 形式的 DSN
+func withStatementTimeout(dsn string, ms int64) (string, error) {
+	v := strconv.FormatInt(ms, 10)
+	if !strings.Contains(dsn, "://") {
+		return dsn + " statement_timeout=" + v, nil
+	}
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", errors.WithMessage(err, "invalid postgres dsn:")
+	}
+	q := u.Query()
+	q.Set("statement_timeout", v)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// registerPoolMetrics 以 go_sql_* 指标导出主库与各副本的 sql.DBStats，db_name 标签为 primary / replica_<i>
+func registerPoolMetrics(mr *metricx.Registry, pg *gorm.DB, replicas *Replicas) error {
+	sqlDB, err := pg.DB()
+	if err != nil {
+		return errors.WithMessage(err, "gorm get sql db failed:")
+	}
+	if err := mr.Register(collectors.NewDBStatsCollector(sqlDB, "primary")); err != nil {
+		return err
+	}
+	for i, r := range replicas.dbs {
+		sqlDB, err := r.db.DB()
+		if err != nil {
+			return errors.WithMessagef(err, "get replica %s sql db:", r.addr)
+		}
+		if err := mr.Register(collectors.NewDBStatsCollector(sqlDB, "replica_"+strconv.Itoa(i))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// poolMetadata 将 sql.DB 连接池统计写入 md，key 以 prefix 开头
+func poolMetadata(md map[string]string, prefix string, sqlDB *sql.DB) {
+	st := sqlDB.Stats()
+	md[prefix+"pool_max_open"] = strconv.Itoa(st.MaxOpenConnections)
+	md[prefix+"pool_open"] = strconv.Itoa(st.OpenConnections)
+	md[prefix+"pool_in_use"] = strconv.Itoa(st.InUse)
+	md[prefix+"pool_idle"] = strconv.Itoa(st.Idle)
+	md[prefix+"pool_wait_count"] = strconv.FormatInt(st.WaitCount, 10)
+	md[prefix+"pool_wait_duration"] = st.WaitDuration.String()
+	md[prefix+"pool_max_idle_closed"] = strconv.FormatInt(st.MaxIdleClosed, 10)
+	md[prefix+"pool_max_idle_time_closed"] = strconv.FormatInt(st.MaxIdleTimeClosed, 10)
+	md[prefix+"pool_max_lifetime_closed"] = strconv.FormatInt(st.MaxLifetimeClosed, 10)
+}
@@ -0,0 +1,162 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/jeffinity/singularity/friendly"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+)
+
+// Redis 部署模式
+const (
+	redisModeStandalone = "standalone"
+	redisModeSentinel   = "sentinel"
+	redisModeCluster    = "cluster"
+)
+
+// 连接池与超时参数，与 friendly.NewRedisCluster 保持一致
+const (
+	redisPoolSize        = 50
+	redisMinIdleConns    = 5
+	redisConnMaxLifetime = 30 * time.Minute
+	redisConnMaxIdleTime = 5 * time.Minute
+	redisTimeout         = 10 * time.Second
+)
+
+// redisMode 按 dsn > sentinel > cluster 的优先级返回生效的模式与集群配置（兼容 data.redis_cluster），未配置时返回空
+func redisMode(c *conf.Bootstrap) (string, *conf.RedisCluster) {
+	rc := c.GetData().GetRedis()
+	switch {
+	case rc.GetDsn() != "":
+		return redisModeStandalone, nil
+	case len(rc.GetSentinel().GetAddrs()) > 0:
+		return redisModeSentinel, nil
+	case len(rc.GetCluster().GetSeeds()) > 0:
+		return redisModeCluster, rc.GetCluster()
+	case len(c.GetData().GetRedisCluster().GetSeeds()) > 0:
+		return redisModeCluster, c.GetData().GetRedisCluster()
+	}
+	return "", nil
+}
+
+// redisAddrs 当前模式下的节点地址（不含账号密码）
+func redisAddrs(c *conf.Bootstrap) []string {
+	mode, cc := redisMode(c)
+	switch mode {
+	case redisModeStandalone:
+		if opts, err := redis.ParseURL(c.GetData().GetRedis().GetDsn()); err == nil {
+			return []string{opts.Addr}
+		}
+	case redisModeSentinel:
+		return c.GetData().GetRedis().GetSentinel().GetAddrs()
+	case redisModeCluster:
+		return cc.GetSeeds()
+	}
+	return nil
+}
+
+// NewRedis 未配置 data.redis / data.redis_cluster 时返回 nil
+func NewRedis(rootCtx context.Context, c *conf.Bootstrap, mLogger log.Logger) (redis.UniversalClient, func(), error) {
+	mode, cc := redisMode(c)
+	switch mode {
+	case "":
+		log.NewHelper(log.With(mLogger, "module", "redis")).Info("redis is not configured, skipped")
+		return nil, func() {}, nil
+	case redisModeCluster:
+		return friendly.NewRedisCluster(rootCtx, mLogger, cc.GetSeeds(), cc.GetPassword(), cc.GetReadOnly())
+	}
+
+	var client redis.UniversalClient
+	switch mode {
+	case redisModeStandalone:
+		opts, err := redis.ParseURL(c.GetData().GetRedis().GetDsn())
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "parse redis dsn failed:")
+		}
+		setRedisPoolOptions(opts)
+		client = redis.NewClient(opts)
+	case redisModeSentinel:
+		sc := c.GetData().GetRedis().GetSentinel()
+		opts := &redis.FailoverOptions{
+			MasterName:       sc.GetMasterName(),
+			SentinelAddrs:    sc.GetAddrs(),
+			SentinelPassword: sc.GetSentinelPassword(),
+			Password:         sc.GetPassword(),
+			DB:               int(sc.GetDb()),
+			RouteRandomly:    sc.GetReplicaRead(),
+		}
+		if opts.MasterName == "" {
+			return nil, nil, errors.New("redis sentinel master_name is empty")
+		}
+		setRedisFailoverPoolOptions(opts)
+		if sc.GetReplicaRead() {
+			// 只读命令随机路由到 master / 副本，需要以集群客户端形式创建
+			client = redis.NewFailoverClusterClient(opts)
+		} else {
+			client = redis.NewFailoverClient(opts)
+		}
+	}
+
+	hl := log.NewHelper(log.With(mLogger, "module", "redis"))
+	cleanup := func() {
+		friendly.CloseQuietly(client)
+	}
+
+	// 非致命探活：只打日志，不影响启动
+	go func() {
+		ctx, cancel := context.WithTimeout(rootCtx, 2*time.Second)
+		defer cancel()
+		if err := client.Ping(ctx).Err(); err != nil {
+			hl.Warnf("Redis (%s) ping failed at startup (will retry on use): %v", mode, err)
+			return
+		}
+		hl.Infof("Redis (%s) ping OK at startup", mode)
+	}()
+	return client, cleanup, nil
+}
+
+// setRedisPoolOptions 仅填充 DSN query（如 ?pool_size=100&dial_timeout=3s）未指定的参数
+func setRedisPoolOptions(o *redis.Options) {
+	if o.PoolSize == 0 {
+		o.PoolSize = redisPoolSize
+	}
+	if o.MinIdleConns == 0 {
+		o.MinIdleConns = redisMinIdleConns
+	}
+	if o.ConnMaxLifetime == 0 {
+		o.ConnMaxLifetime = redisConnMaxLifetime
+	}
+	if o.ConnMaxIdleTime == 0 {
+		o.ConnMaxIdleTime = redisConnMaxIdleTime
+	}
+	if o.DialTimeout == 0 {
+		o.DialTimeout = redisTimeout
+	}
+	if o.ReadTimeout == 0 {
+		o.ReadTimeout = redisTimeout
+	}
+	if o.WriteTimeout == 0 {
+		o.WriteTimeout = redisTimeout
+	}
+	if o.PoolTimeout == 0 {
+		o.PoolTimeout = redisTimeout
+	}
+	o.ContextTimeoutEnabled = true
+}
+
+func setRedisFailoverPoolOptions(o *redis.FailoverOptions) {
+	o.PoolSize = redisPoolSize
+	o.MinIdleConns = redisMinIdleConns
+	o.ConnMaxLifetime = redisConnMaxLifetime
+	o.ConnMaxIdleTime = redisConnMaxIdleTime
+	o.DialTimeout = redisTimeout
+	o.ReadTimeout = redisTimeout
+	o.WriteTimeout = redisTimeout
+	o.PoolTimeout = redisTimeout
+	o.ContextTimeoutEnabled = true
+}
@@ -0,0 +1,122 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/jeffinity/singularity/friendly"
+	"github.com/pkg/errors"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+)
+
+const defaultMaxReplicaLag = 30 * time.Second
+
+// replicaLagSQL 副本回放延迟（秒）；主库或已回放完所有 WAL 的副本返回 0，避免空闲时延迟持续增长
+const replicaLagSQL = `SELECT CASE
+	WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END`
+
+// Replicas Postgres 只读副本，未配置 data.postgres.replicas 时为空
+type Replicas struct {
+	dbs    []*replica
+	maxLag time.Duration
+}
+
+type replica struct {
+	addr string
+	db   *gorm.DB
+	lag  atomic.Int64 // 最近一次检查得到的复制延迟（纳秒）
+}
+
+func NewReplicas(c *conf.Bootstrap, logger log.Logger) (*Replicas, func(), error) {
+	pc := c.GetData().GetPostgres()
+	if len(pc.GetReplicas()) > 0 && pc.GetDsn() == "" {
+		return nil, nil, errors.New("postgres replicas require data.postgres.dsn")
+	}
+	rs := &Replicas{maxLag: defaultMaxReplicaLag}
+	if pc.GetMaxReplicaLag() != nil {
+		rs.maxLag = pc.GetMaxReplicaLag().AsDuration()
+	}
+	cleanup := func() {
+		for _, r := range rs.dbs {
+			if sqlDB, err := r.db.DB(); err == nil {
+				friendly.CloseQuietly(sqlDB)
+			}
+		}
+	}
+
+	for i, dsn := range pc.GetReplicas() {
+		db, err := openPostgres(c, dsn, logger)
+		if err != nil {
+			cleanup()
+			return nil, nil, errors.WithMessagef(err, "open postgres replica #%d failed:", i)
+		}
+		addr, _ := parsePostgresDSN(dsn)
+		rs.dbs = append(rs.dbs, &replica{addr: addr, db: db})
+	}
+	return rs, cleanup, nil
+}
+
+// registerResolver 在主库连接上注册读写分离：事务外的查询按轮询分发到副本，写操作与事务始终走主库
+func (rs *Replicas) registerResolver(pg *gorm.DB) error {
+	if len(rs.dbs) == 0 {
+		return nil
+	}
+	dialectors := make([]gorm.Dialector, 0, len(rs.dbs))
+	for _, r := range rs.dbs {
+		sqlDB, err := r.db.DB()
+		if err != nil {
+			return errors.WithMessagef(err, "get replica %s sql db:", r.addr)
+		}
+		dialectors = append(dialectors, postgres.New(postgres.Config{Conn: sqlDB}))
+	}
+	return errors.WithStack(pg.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: dialectors,
+		Policy:   dbresolver.StrictRoundRobinPolicy(),
+	})))
+}
+
+// check 检查所有副本的连通性与复制延迟，存在副本不可用或延迟超过 max_replica_lag 时返回汇总所有异常副本的错误
+func (rs *Replicas) check(ctx context.Context) error {
+	var errs []string
+	for _, r := range rs.dbs {
+		var seconds float64
+		if err := r.db.WithContext(ctx).Raw(replicaLagSQL).Scan(&seconds).Error; err != nil {
+			errs = append(errs, "replica "+r.addr+": "+err.Error())
+			continue
+		}
+		lag := time.Duration(seconds * float64(time.Second))
+		r.lag.Store(int64(lag))
+		if lag > rs.maxLag {
+			errs = append(errs, fmt.Sprintf("replica %s lag %s exceeds %s", r.addr, lag.Round(time.Millisecond), rs.maxLag))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// metadata 各副本地址、最近一次检查的复制延迟与连接池统计
+func (rs *Replicas) metadata(context.Context) map[string]string {
+	md := map[string]string{"max_lag_seconds": strconv.FormatFloat(rs.maxLag.Seconds(), 'f', -1, 64)}
+	for i, r := range rs.dbs {
+		p := "replica_" + strconv.Itoa(i) + "_"
+		md[p+"addr"] = r.addr
+		md[p+"lag_seconds"] = strconv.FormatFloat(time.Duration(r.lag.Load()).Seconds(), 'f', 3, 64)
+		if sqlDB, err := r.db.DB(); err == nil {
+			poolMetadata(md, p, sqlDB)
+		}
+	}
+	return md
+}
@@ -0,0 +1,60 @@
+package data
+
+import (
+	"strings"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/jeffinity/singularity/pgx"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+)
+
+// sqlitePragmas 每个连接建立时执行：启用外键约束，写锁冲突时等待而不是立即返回 SQLITE_BUSY
+const sqlitePragmas = "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+
+// openSqlite 打开内嵌 SQLite，仅用于本地开发与单测：
+// 连接池固定为单连接，保证 ":memory:" 库在进程内唯一（每个连接各自拥有独立的内存库），同时避免并发写冲突
+func openSqlite(c *conf.Bootstrap, mLogger log.Logger) (*gorm.DB, error) {
+	dsn := c.GetData().GetSqlite().GetPath()
+	if dsn == "" {
+		return nil, errors.New("data.sqlite.path is empty, use a file path or \":memory:\"")
+	}
+	if strings.Contains(dsn, "?") {
+		dsn += "&" + sqlitePragmas
+	} else {
+		dsn += "?" + sqlitePragmas
+	}
+
+	hl := log.NewHelper(log.With(mLogger, "module", "gorm-sqlite"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: pgx.NewGormLogger(hl, logger.Config{
+			SlowThreshold:             time.Second,
+			LogLevel:                  pgx.ParsePostgresLogLevel(c.GetLog().GetLevel()),
+			IgnoreRecordNotFoundError: true,
+		}),
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "gorm open sqlite failed:")
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, errors.WithMessage(err, "gorm get sql db failed:")
+	}
+	sqlDB.SetMaxOpenConns(1)
+	sqlDB.SetMaxIdleConns(1)
+	// 关闭连接会丢失内存库，不设置存活时间
+	sqlDB.SetConnMaxLifetime(0)
+	sqlDB.SetConnMaxIdleTime(0)
+	return db, nil
+}
+
+// isMemorySqlite 是否为进程内存库：":memory:" 或 "file::memory:" / mode=memory 形式的 URI
+func isMemorySqlite(path string) bool {
+	return strings.HasPrefix(path, ":memory:") || strings.HasPrefix(path, "file::memory:") || strings.Contains(path, "mode=memory")
+}
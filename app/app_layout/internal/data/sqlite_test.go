@@ -0,0 +1,48 @@
+package data
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/health"
+)
+
+func TestNewDataSqliteMigration(t *testing.T) {
+	tests := []struct {
+		path     string
+		migrated bool
+	}{
+		{path: ":memory:", migrated: true},
+		{path: "file::memory:?cache=shared", migrated: true},
+		{path: filepath.Join(t.TempDir(), "app.db"), migrated: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			c := &conf.Bootstrap{Data: &conf.Data{Sqlite: &conf.Sqlite{Path: tt.path}}}
+			db, err := NewDB(c, &Replicas{}, log.DefaultLogger)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = sqlDB.Close() })
+
+			_, cleanup, err := NewData(c, db, &Replicas{}, nil, health.NewRegistry(c), newTestMetrics(t), log.DefaultLogger)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(cleanup)
+
+			for _, table := range []any{&Hello{}, &OutboxEvent{}} {
+				if got := db.Migrator().HasTable(table); got != tt.migrated {
+					t.Fatalf("table %T exists: %v, want %v", table, got, tt.migrated)
+				}
+			}
+		})
+	}
+}
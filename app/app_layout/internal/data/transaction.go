@@ -0,0 +1,100 @@
+package data
+
+import (
+	"context"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/biz"
+)
+
+type txKey struct{}
+
+// txState ctx 中保存的事务；每层事务（含 savepoint）有独立的 hooks，成功后并入上一层
+type txState struct {
+	db    *gorm.DB
+	hooks *txHooks
+}
+
+// txHooks 最外层事务提交后执行的回调
+type txHooks struct {
+	mu  sync.Mutex
+	fns []func(ctx context.Context)
+}
+
+func (h *txHooks) add(fns ...func(ctx context.Context)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fns = append(h.fns, fns...)
+}
+
+func NewTransaction(d *Data) biz.Transaction {
+	return d
+}
+
+// InTx 实现 biz.Transaction，事务保存在 ctx 中，repo 通过 DB(ctx) 透明获取；
+// 已在事务中时 gorm 以 savepoint 执行嵌套事务，panic 时回滚后继续向上抛出；
+// savepoint 回滚时其中注册的 AfterCommit 回调一并丢弃
+func (d *Data) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if st, ok := ctx.Value(txKey{}).(*txState); ok {
+		hooks := &txHooks{}
+		err := st.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(context.WithValue(ctx, txKey{}, &txState{db: tx, hooks: hooks}))
+		})
+		if err != nil {
+			return err
+		}
+		st.hooks.add(hooks.fns...)
+		return nil
+	}
+
+	if d.pg == nil {
+		return ErrDatabaseNotConfigured
+	}
+	hooks := &txHooks{}
+	err := d.pg.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, &txState{db: tx, hooks: hooks}))
+	})
+	if err != nil {
+		return err
+	}
+	for _, hook := range hooks.fns {
+		hook(ctx)
+	}
+	return nil
+}
+
+// DB repo 访问数据库的入口：ctx 中有事务时返回该事务，否则返回绑定 ctx 的连接池；
+// 配置了只读副本时事务外的查询走副本，ctx 经 biz.WithReadPrimary 标记时走主库；
+// 未配置数据库时返回 ErrDatabaseNotConfigured
+func (d *Data) DB(ctx context.Context) (*gorm.DB, error) {
+	if st, ok := ctx.Value(txKey{}).(*txState); ok {
+		return st.db.WithContext(ctx), nil
+	}
+	if d.pg == nil {
+		return nil, ErrDatabaseNotConfigured
+	}
+	if biz.ReadPrimary(ctx) {
+		// Clauses 返回的实例会累积后续链式条件，以新 Session 返回，与 WithContext 一样可安全复用
+		return d.pg.WithContext(ctx).Clauses(dbresolver.Write).Session(&gorm.Session{}), nil
+	}
+	return d.pg.WithContext(ctx), nil
+}
+
+func (d *Data) inTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*txState)
+	return ok
+}
+
+// AfterCommit ctx 处于事务中时，在最外层事务提交后执行 fn（所在事务或 savepoint 回滚时不执行），否则立即执行；
+// 用于缓存失效、消息通知等必须在数据可见后进行的操作
+func (d *Data) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	st, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		fn(ctx)
+		return
+	}
+	st.hooks.add(fn)
+}
@@ -0,0 +1,209 @@
+package data_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/biz"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/test"
+)
+
+var errRollback = errors.New("rollback")
+
+// helloNames 按 ID 顺序返回已提交的以 prefix 开头的 hello 名称（去掉 prefix）
+func helloNames(t *testing.T, r *test.Resource, prefix string) []string {
+	t.Helper()
+	hellos, _, err := r.HelloRepo().List(r.Context(), biz.HelloFilter{NamePrefix: prefix, Limit: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, len(hellos))
+	for _, h := range hellos {
+		names = append(names, strings.TrimPrefix(h.Name, prefix))
+	}
+	slices.Reverse(names)
+	return names
+}
+
+func saveHello(ctx context.Context, r *test.Resource, name string) error {
+	_, err := r.HelloRepo().Save(ctx, &biz.Hello{Name: name})
+	return err
+}
+
+func TestInTxCommit(t *testing.T) {
+	r := test.InitTest()
+	d, p := r.Data(), namePrefix()
+
+	err := d.InTx(r.Context(), func(ctx context.Context) error {
+		if err := saveHello(ctx, r, p+"outer"); err != nil {
+			return err
+		}
+		return d.InTx(ctx, func(ctx context.Context) error {
+			return saveHello(ctx, r, p+"inner")
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := helloNames(t, r, p), []string{"outer", "inner"}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestInTxSavepointRollback(t *testing.T) {
+	r := test.InitTest()
+	d, p := r.Data(), namePrefix()
+
+	err := d.InTx(r.Context(), func(ctx context.Context) error {
+		if err := saveHello(ctx, r, p+"before"); err != nil {
+			return err
+		}
+		// 内层失败只回滚自己的 savepoint，外层忽略错误后继续
+		err := d.InTx(ctx, func(ctx context.Context) error {
+			if err := saveHello(ctx, r, p+"inner"); err != nil {
+				return err
+			}
+			return errRollback
+		})
+		if !errors.Is(err, errRollback) {
+			t.Fatalf("got %v, want %v", err, errRollback)
+		}
+		return saveHello(ctx, r, p+"after")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := helloNames(t, r, p), []string{"before", "after"}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestInTxRollback(t *testing.T) {
+	r := test.InitTest()
+	d, p := r.Data(), namePrefix()
+
+	// 外层失败时已提交的 savepoint 一并回滚
+	err := d.InTx(r.Context(), func(ctx context.Context) error {
+		if err := d.InTx(ctx, func(ctx context.Context) error {
+			return saveHello(ctx, r, p+"inner")
+		}); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("got %v, want %v", err, errRollback)
+	}
+	if got := helloNames(t, r, p); len(got) != 0 {
+		t.Fatalf("got %v, want none", got)
+	}
+}
+
+func TestInTxPanic(t *testing.T) {
+	r := test.InitTest()
+	d, p := r.Data(), namePrefix()
+
+	func() {
+		defer func() {
+			if p := recover(); p != "boom" {
+				t.Fatalf("recovered %v, want boom", p)
+			}
+		}()
+		_ = d.InTx(r.Context(), func(ctx context.Context) error {
+			if err := saveHello(ctx, r, p+"outer"); err != nil {
+				return err
+			}
+			return d.InTx(ctx, func(ctx context.Context) error {
+				if err := saveHello(ctx, r, p+"inner"); err != nil {
+					return err
+				}
+				panic("boom")
+			})
+		})
+	}()
+	if got := helloNames(t, r, p); len(got) != 0 {
+		t.Fatalf("got %v, want none", got)
+	}
+
+	// 回滚后连接已归还，后续事务可正常执行
+	if err := d.InTx(r.Context(), func(ctx context.Context) error { return saveHello(ctx, r, p+"next") }); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := helloNames(t, r, p), []string{"next"}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestAfterCommitSavepoint(t *testing.T) {
+	r := test.InitTest()
+	d := r.Data()
+
+	var ran []string
+	hook := func(name string) func(context.Context) {
+		return func(context.Context) { ran = append(ran, name) }
+	}
+	err := d.InTx(r.Context(), func(ctx context.Context) error {
+		d.AfterCommit(ctx, hook("outer"))
+		if err := d.InTx(ctx, func(ctx context.Context) error {
+			d.AfterCommit(ctx, hook("committed"))
+			return d.InTx(ctx, func(ctx context.Context) error {
+				d.AfterCommit(ctx, hook("nested"))
+				return nil
+			})
+		}); err != nil {
+			return err
+		}
+		// 回滚的 savepoint 中注册的回调被丢弃
+		if err := d.InTx(ctx, func(ctx context.Context) error {
+			d.AfterCommit(ctx, hook("rolled back"))
+			return errRollback
+		}); !errors.Is(err, errRollback) {
+			t.Fatalf("got %v, want %v", err, errRollback)
+		}
+		if len(ran) != 0 {
+			t.Fatalf("hooks %v ran before commit", ran)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"outer", "committed", "nested"}; !slices.Equal(ran, want) {
+		t.Fatalf("ran %v, want %v", ran, want)
+	}
+}
+
+func TestAfterCommitRollback(t *testing.T) {
+	r := test.InitTest()
+	d := r.Data()
+
+	var ran bool
+	err := d.InTx(r.Context(), func(ctx context.Context) error {
+		if err := d.InTx(ctx, func(ctx context.Context) error {
+			d.AfterCommit(ctx, func(context.Context) { ran = true })
+			return nil
+		}); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("got %v, want %v", err, errRollback)
+	}
+	if ran {
+		t.Fatal("hook of a committed savepoint ran after the outer transaction rolled back")
+	}
+}
+
+func TestAfterCommitWithoutTx(t *testing.T) {
+	r := test.InitTest()
+
+	var ran bool
+	r.Data().AfterCommit(r.Context(), func(context.Context) { ran = true })
+	if !ran {
+		t.Fatal("hook should run immediately outside a transaction")
+	}
+}
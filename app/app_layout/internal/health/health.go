@@ -0,0 +1,231 @@
+package health
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/wire"
+	"github.com/pkg/errors"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+)
+
+// ProviderSet is health providers.
+var ProviderSet = wire.NewSet(NewRegistry, NewStartup, NewProber)
+
+// DefaultTimeout 单项检查超时
+const DefaultTimeout = 800 * time.Millisecond
+
+// Checker 依赖检查项，由 data 等层在构造依赖时注册到 Registry
+type Checker interface {
+	// Name 依赖名，如 "postgres", "redis", "kafka"，在 Registry 内唯一
+	Name() string
+	// Check 返回 nil 表示依赖可用
+	Check(ctx context.Context) error
+}
+
+// CriticalChecker 可选实现：声明检查项是否为关键依赖，未实现时视为关键依赖。
+// 非关键依赖（如缓存）失败时整体状态为 DEGRADED，Readiness 不受影响
+type CriticalChecker interface {
+	Critical() bool
+}
+
+// SlowChecker 可选实现：声明为慢检查，StatusRequest.skip_slow 时跳过
+type SlowChecker interface {
+	Slow() bool
+}
+
+// MetadataChecker 可选实现：返回检查项的详细信息（地址、连接池统计等），StatusRequest.verbose 时返回
+type MetadataChecker interface {
+	Metadata(ctx context.Context) map[string]string
+}
+
+// Option CheckerFunc / TCPChecker 选项
+type Option func(*checkerFunc)
+
+// Optional 声明为非关键依赖
+func Optional() Option {
+	return func(c *checkerFunc) { c.optional = true }
+}
+
+// Slow 声明为慢检查
+func Slow() Option {
+	return func(c *checkerFunc) { c.slow = true }
+}
+
+// WithMetadata 设置详细信息的获取方式
+func WithMetadata(fn func(context.Context) map[string]string) Option {
+	return func(c *checkerFunc) { c.metadata = fn }
+}
+
+type checkerFunc struct {
+	name     string
+	fn       func(context.Context) error
+	optional bool
+	slow     bool
+	metadata func(context.Context) map[string]string
+}
+
+func (c *checkerFunc) Name() string                    { return c.name }
+func (c *checkerFunc) Check(ctx context.Context) error { return c.fn(ctx) }
+func (c *checkerFunc) Critical() bool                  { return !c.optional }
+func (c *checkerFunc) Slow() bool                      { return c.slow }
+
+func (c *checkerFunc) Metadata(ctx context.Context) map[string]string {
+	if c.metadata == nil {
+		return nil
+	}
+	return c.metadata(ctx)
+}
+
+// CheckerFunc 以函数构造 Checker
+func CheckerFunc(name string, fn func(context.Context) error, opts ...Option) Checker {
+	c := &checkerFunc{name: name, fn: fn}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// TCPChecker 依次拨号 addrs，任一可连通即视为可用（如 kafka brokers）
+func TCPChecker(name string, addrs []string, opts ...Option) Checker {
+	return CheckerFunc(name, func(ctx context.Context) error {
+		if len(addrs) == 0 {
+			return errors.New("no address provided")
+		}
+		var lastErr error
+		for _, addr := range addrs {
+			var d net.Dialer
+			c, err := d.DialContext(ctx, "tcp", addr)
+			if err != nil {
+				lastErr = errors.WithMessagef(err, "tcp dial %s:", addr)
+				continue
+			}
+			_ = c.Close()
+			return nil
+		}
+		return lastErr
+	}, opts...)
+}
+
+// Result 单项检查结果
+type Result struct {
+	Name     string
+	Critical bool
+	Err      error
+	Latency  time.Duration
+}
+
+// Registry 依赖检查项注册表，HealthService 据此执行 Readiness / Status
+type Registry struct {
+	overrides map[string]*conf.HealthCheck
+
+	mu       sync.RWMutex
+	checkers map[string]Checker
+}
+
+func NewRegistry(c *conf.Bootstrap) *Registry {
+	return &Registry{
+		overrides: c.GetHealth().GetChecks(),
+		checkers:  make(map[string]Checker),
+	}
+}
+
+// Register 注册检查项，同名检查项返回错误
+func (r *Registry) Register(cs ...Checker) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, c := range cs {
+		if _, ok := r.checkers[c.Name()]; ok {
+			return errors.Errorf("health checker %q already registered", c.Name())
+		}
+		r.checkers[c.Name()] = c
+	}
+	return nil
+}
+
+// Checkers 按名称排序返回已注册的检查项
+func (r *Registry) Checkers() []Checker {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	cs := make([]Checker, 0, len(r.checkers))
+	for _, c := range r.checkers {
+		cs = append(cs, c)
+	}
+	sort.Slice(cs, func(i, j int) bool { return cs[i].Name() < cs[j].Name() })
+	return cs
+}
+
+// Critical 检查项是否为关键依赖：conf.Health.checks 配置优先，其次为检查项自身声明，默认 true
+func (r *Registry) Critical(c Checker) bool {
+	if o, ok := r.overrides[c.Name()]; ok && o.Critical != nil {
+		return o.GetCritical()
+	}
+	if cc, ok := c.(CriticalChecker); ok {
+		return cc.Critical()
+	}
+	return true
+}
+
+// Slow 检查项是否为慢检查：conf.Health.checks 配置优先，其次为检查项自身声明，默认 false
+func (r *Registry) Slow(c Checker) bool {
+	if o, ok := r.overrides[c.Name()]; ok && o.Slow != nil {
+		return o.GetSlow()
+	}
+	if sc, ok := c.(SlowChecker); ok {
+		return sc.Slow()
+	}
+	return false
+}
+
+// Metadata 返回检查项的详细信息，检查项不存在或未实现 MetadataChecker 时返回 nil
+func (r *Registry) Metadata(ctx context.Context, name string) map[string]string {
+	r.mu.RLock()
+	c, ok := r.checkers[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	if mc, ok := c.(MetadataChecker); ok {
+		return mc.Metadata(ctx)
+	}
+	return nil
+}
+
+// RunAll 并发执行全部检查项，每项超时 timeout，结果按名称排序
+func (r *Registry) RunAll(ctx context.Context, timeout time.Duration) []Result {
+	cs := r.Checkers()
+	results := make([]Result, len(cs))
+
+	var wg sync.WaitGroup
+	for i, c := range cs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = Run(ctx, c, timeout)
+			results[i].Critical = r.Critical(c)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// Run 执行单项检查，panic 视为失败
+func Run(ctx context.Context, c Checker, timeout time.Duration) (res Result) {
+	start := time.Now()
+	res.Name = c.Name()
+	defer func() {
+		if e := recover(); e != nil {
+			res.Err = errors.Errorf("check panic: %v", e)
+		}
+		res.Latency = time.Since(start)
+	}()
+
+	cctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	res.Err = c.Check(cctx)
+	return res
+}
@@ -0,0 +1,63 @@
+package health
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/metricx"
+	healthv1 "github.com/jeffinity/app-layout/pkg/health"
+)
+
+// proberMetrics 检查结果指标，便于直接基于 /metrics 告警：
+// - health_check_up{name, critical}：当前（防抖后）状态，1 为 UP，0 为 DOWN
+// - health_check_duration_seconds{name}：单项检查耗时
+// - health_check_transitions_total{name, from, to}：状态变更次数
+type proberMetrics struct {
+	up          *prometheus.GaugeVec
+	duration    *prometheus.HistogramVec
+	transitions *prometheus.CounterVec
+}
+
+func newProberMetrics(mr *metricx.Registry) (*proberMetrics, error) {
+	m := &proberMetrics{
+		up: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: mr.Namespace(),
+			Name:      "health_check_up",
+			Help:      "Whether the dependency health check is up (1) or down (0), after flap damping.",
+		}, []string{"name", "critical"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: mr.Namespace(),
+			Name:      "health_check_duration_seconds",
+			Help:      "Latency of dependency health checks.",
+			Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"name"}),
+		transitions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: mr.Namespace(),
+			Name:      "health_check_transitions_total",
+			Help:      "Number of dependency health check state transitions.",
+		}, []string{"name", "from", "to"}),
+	}
+	if err := mr.Register(m.up, m.duration, m.transitions); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *proberMetrics) observe(st CheckState, critical bool) {
+	up := 0.0
+	if st.Status == healthv1.Status_STATUS_UP {
+		up = 1
+	}
+	m.up.WithLabelValues(st.Name, strconv.FormatBool(critical)).Set(up)
+	m.duration.WithLabelValues(st.Name).Observe(st.Latency.Seconds())
+}
+
+func (m *proberMetrics) transit(t Transition) {
+	m.transitions.WithLabelValues(t.Name, statusLabel(t.From), statusLabel(t.To)).Inc()
+}
+
+func statusLabel(s healthv1.Status) string {
+	return strings.ToLower(strings.TrimPrefix(s.String(), "STATUS_"))
+}
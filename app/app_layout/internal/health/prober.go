@@ -0,0 +1,323 @@
+package health
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/metricx"
+	healthv1 "github.com/jeffinity/app-layout/pkg/health"
+)
+
+const (
+	defaultInterval         = 10 * time.Second
+	defaultFailureThreshold = 3
+	defaultSuccessThreshold = 1
+	defaultHistorySize      = 100
+
+	reasonPending = "waiting for first probe"
+
+	phaseFirstProbe = "health_first_probe"
+)
+
+// CheckState 检查项的缓存状态
+type CheckState struct {
+	Name      string
+	Critical  bool // 是否为关键依赖，见 Registry.Critical
+	Slow      bool // 是否为慢检查，见 Registry.Slow
+	Status    healthv1.Status
+	Reason    string        // 最近一次失败的错因，UP 时为空
+	Latency   time.Duration // 最近一次检查耗时
+	Since     time.Time     // 进入当前状态的时间
+	CheckedAt time.Time     // 最近一次检查时间，零值表示尚未检查
+}
+
+// Transition 检查项状态变更
+type Transition struct {
+	Name     string
+	From, To healthv1.Status
+	At       time.Time
+	Reason   string
+}
+
+type checkState struct {
+	CheckState
+	failures  int // 连续失败次数
+	successes int // 连续成功次数
+}
+
+// Prober 按 conf.Health.interval 在后台执行 Registry 中的检查项并缓存结果：
+// - 首次检查结果直接作为初始状态
+// - UP 状态下连续失败 failure_threshold 次才变为 DOWN，DOWN 状态下连续成功 success_threshold 次才恢复 UP（防抖）
+// - 状态变化时更新 Since 并记录到定长的变更历史，并通知 Changed 的订阅方
+//
+// Prober 实现 transport.Server，随 kratos app 启停。
+type Prober struct {
+	reg              *Registry
+	interval         time.Duration
+	timeout          time.Duration
+	failureThreshold int
+	successThreshold int
+	historySize      int
+	metrics          *proberMetrics
+	firstProbe       *Phase
+	log              *log.Helper
+
+	mu      sync.RWMutex
+	states  map[string]*checkState
+	history []Transition // 环形缓冲，next 为下一个写入位置
+	next    int
+	probed  bool
+	changed chan struct{} // 状态变化时 close 并替换，见 Changed
+
+	stop chan struct{}
+	once sync.Once
+}
+
+func NewProber(c *conf.Bootstrap, reg *Registry, startup *Startup, mr *metricx.Registry, logger log.Logger) (*Prober, error) {
+	pm, err := newProberMetrics(mr)
+	if err != nil {
+		return nil, err
+	}
+
+	hc := c.GetHealth()
+	p := &Prober{
+		reg:              reg,
+		interval:         defaultInterval,
+		timeout:          DefaultTimeout,
+		failureThreshold: defaultFailureThreshold,
+		successThreshold: defaultSuccessThreshold,
+		historySize:      defaultHistorySize,
+		metrics:          pm,
+		firstProbe:       startup.Register(phaseFirstProbe),
+		log:              log.NewHelper(log.With(logger, "module", "app_layout/health.Prober")),
+		states:           make(map[string]*checkState),
+		changed:          make(chan struct{}),
+		stop:             make(chan struct{}),
+	}
+	if hc.GetInterval() != nil {
+		p.interval = hc.GetInterval().AsDuration()
+	}
+	if hc.GetTimeout() != nil {
+		p.timeout = hc.GetTimeout().AsDuration()
+	}
+	if hc.GetFailureThreshold() > 0 {
+		p.failureThreshold = int(hc.GetFailureThreshold())
+	}
+	if hc.GetSuccessThreshold() > 0 {
+		p.successThreshold = int(hc.GetSuccessThreshold())
+	}
+	if hc.GetHistorySize() > 0 {
+		p.historySize = int(hc.GetHistorySize())
+	}
+	return p, nil
+}
+
+func (p *Prober) Start(ctx context.Context) error {
+	p.log.Infof("health prober started, interval: %s, failure threshold: %d", p.interval, p.failureThreshold)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	// 首轮检查作为启动阶段，完成后才有可信的 Readiness 结果
+	p.firstProbe.Start()
+	p.ProbeOnce(ctx)
+	p.firstProbe.Done(nil)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-p.stop:
+			return nil
+		case <-ticker.C:
+			p.ProbeOnce(ctx)
+		}
+	}
+}
+
+func (p *Prober) Stop(_ context.Context) error {
+	p.once.Do(func() { close(p.stop) })
+	return nil
+}
+
+// ProbeOnce 立即执行一轮检查并更新缓存
+func (p *Prober) ProbeOnce(ctx context.Context) {
+	results := p.reg.RunAll(ctx, p.timeout)
+	now := time.Now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	changed := false
+	for _, res := range results {
+		if p.observe(res, now) {
+			changed = true
+		}
+		p.metrics.observe(p.states[res.Name].CheckState, res.Critical)
+	}
+	p.probed = true
+	if changed {
+		close(p.changed)
+		p.changed = make(chan struct{})
+	}
+}
+
+// Changed 返回在下一次状态变化（首次检查或状态变更）时关闭的 channel，
+// 调用方应先取 channel 再读取 Snapshot，避免错过两者之间发生的变化
+func (p *Prober) Changed() <-chan struct{} {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.changed
+}
+
+// observe 更新检查项状态，返回状态是否变化
+func (p *Prober) observe(res Result, now time.Time) bool {
+	reason := ""
+	if res.Err != nil {
+		reason = res.Err.Error()
+	}
+
+	st, ok := p.states[res.Name]
+	if !ok {
+		status := healthv1.Status_STATUS_UP
+		if res.Err != nil {
+			status = healthv1.Status_STATUS_DOWN
+		}
+		p.states[res.Name] = &checkState{CheckState: CheckState{
+			Name: res.Name, Status: status, Reason: reason, Latency: res.Latency, Since: now, CheckedAt: now,
+		}}
+		return true
+	}
+
+	st.Latency = res.Latency
+	st.CheckedAt = now
+	if res.Err != nil {
+		st.failures++
+		st.successes = 0
+		st.Reason = reason
+		if st.Status != healthv1.Status_STATUS_DOWN && st.failures >= p.failureThreshold {
+			p.transit(st, healthv1.Status_STATUS_DOWN, reason, now)
+			return true
+		}
+		return false
+	}
+
+	st.successes++
+	st.failures = 0
+	if st.Status == healthv1.Status_STATUS_DOWN {
+		if st.successes >= p.successThreshold {
+			p.transit(st, healthv1.Status_STATUS_UP, "", now)
+			st.Reason = ""
+			return true
+		}
+		return false
+	}
+	st.Reason = ""
+	return false
+}
+
+func (p *Prober) transit(st *checkState, to healthv1.Status, reason string, now time.Time) {
+	t := Transition{Name: st.Name, From: st.Status, To: to, At: now, Reason: reason}
+	if to == healthv1.Status_STATUS_DOWN {
+		p.log.Errorf("health check %s: %s -> %s, reason: %s", st.Name, st.Status, to, reason)
+	} else {
+		p.log.Infof("health check %s: %s -> %s", st.Name, st.Status, to)
+	}
+
+	st.Status = to
+	st.Since = now
+	p.metrics.transit(t)
+	if len(p.history) < p.historySize {
+		p.history = append(p.history, t)
+		return
+	}
+	p.history[p.next] = t
+	p.next = (p.next + 1) % p.historySize
+}
+
+// Snapshot 返回全部已注册检查项的缓存状态（按名称排序），尚未检查的项为 DOWN
+func (p *Prober) Snapshot() []CheckState {
+	cs := p.reg.Checkers()
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	states := make([]CheckState, 0, len(cs))
+	for _, c := range cs {
+		st := CheckState{Name: c.Name(), Status: healthv1.Status_STATUS_DOWN, Reason: reasonPending}
+		if cached, ok := p.states[c.Name()]; ok {
+			st = cached.CheckState
+		}
+		st.Critical = p.reg.Critical(c)
+		st.Slow = p.reg.Slow(c)
+		states = append(states, st)
+	}
+	return states
+}
+
+// Overall 汇总状态：任一关键依赖 DOWN 为 DOWN，仅非关键依赖 DOWN 为 DEGRADED，否则为 UP
+func Overall(states []CheckState) healthv1.Status {
+	overall := healthv1.Status_STATUS_UP
+	for _, st := range states {
+		if st.Status != healthv1.Status_STATUS_DOWN {
+			continue
+		}
+		if st.Critical {
+			return healthv1.Status_STATUS_DOWN
+		}
+		overall = healthv1.Status_STATUS_DEGRADED
+	}
+	return overall
+}
+
+// Ready 就绪判定：启动完成且无关键依赖 DOWN 时返回 nil，否则返回未就绪原因
+func Ready(startup *Startup, states []CheckState) error {
+	if err := startup.Check(); err != nil {
+		return err
+	}
+	var errs []string
+	for _, st := range states {
+		if st.Critical && st.Status == healthv1.Status_STATUS_DOWN {
+			errs = append(errs, st.Name+": "+st.Reason)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Metadata 返回检查项的详细信息，见 Registry.Metadata
+func (p *Prober) Metadata(ctx context.Context, name string) map[string]string {
+	return p.reg.Metadata(ctx, name)
+}
+
+// Probed 是否已完成首轮检查
+func (p *Prober) Probed() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.probed
+}
+
+// History 返回状态变更历史，最近的在前；name 为空返回全部检查项，limit <= 0 不限制条数
+func (p *Prober) History(name string, limit int) []Transition {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var ts []Transition
+	n := len(p.history)
+	for i := 0; i < n; i++ {
+		// 从最近写入的位置倒序遍历环形缓冲
+		t := p.history[(p.next-1-i+n)%n]
+		if name != "" && t.Name != name {
+			continue
+		}
+		ts = append(ts, t)
+		if limit > 0 && len(ts) >= limit {
+			break
+		}
+	}
+	return ts
+}
@@ -0,0 +1,143 @@
+package health
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	healthv1 "github.com/jeffinity/app-layout/pkg/health"
+)
+
+// Startup 跟踪应用初始化阶段（数据库迁移、缓存预热、配置加载等），供 Startup 探针使用：
+// 初始化任务在构造时 Register 阶段，开始执行时 Start，结束时 Done；
+// 全部已注册阶段 Done 且无错误时视为启动完成。
+type Startup struct {
+	mu     sync.RWMutex
+	phases []*Phase
+}
+
+func NewStartup() *Startup {
+	return &Startup{}
+}
+
+// Phase 单个初始化阶段
+type Phase struct {
+	name string
+
+	mu         sync.RWMutex
+	state      healthv1.PhaseState
+	startedAt  time.Time
+	finishedAt time.Time
+	err        error
+}
+
+// PhaseInfo 初始化阶段快照
+type PhaseInfo struct {
+	Name       string
+	State      healthv1.PhaseState
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Duration   time.Duration // 执行中为已执行时长
+	Err        error
+}
+
+// Register 注册一个尚未开始的初始化阶段，同名阶段返回已注册的实例
+func (s *Startup) Register(name string) *Phase {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, p := range s.phases {
+		if p.name == name {
+			return p
+		}
+	}
+	p := &Phase{name: name, state: healthv1.PhaseState_PHASE_STATE_PENDING}
+	s.phases = append(s.phases, p)
+	return p
+}
+
+// Begin 注册并立即开始一个初始化阶段
+func (s *Startup) Begin(name string) *Phase {
+	p := s.Register(name)
+	p.Start()
+	return p
+}
+
+// Start 标记阶段开始执行，重复调用无效
+func (p *Phase) Start() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.state != healthv1.PhaseState_PHASE_STATE_PENDING {
+		return
+	}
+	p.state = healthv1.PhaseState_PHASE_STATE_RUNNING
+	p.startedAt = time.Now()
+}
+
+// Done 标记阶段结束，err 非 nil 时为失败；未 Start 的阶段以当前时间作为开始时间
+func (p *Phase) Done(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	if p.startedAt.IsZero() {
+		p.startedAt = now
+	}
+	p.finishedAt = now
+	p.err = err
+	p.state = healthv1.PhaseState_PHASE_STATE_DONE
+	if err != nil {
+		p.state = healthv1.PhaseState_PHASE_STATE_FAILED
+	}
+}
+
+func (p *Phase) info() PhaseInfo {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	pi := PhaseInfo{
+		Name:       p.name,
+		State:      p.state,
+		StartedAt:  p.startedAt,
+		FinishedAt: p.finishedAt,
+		Err:        p.err,
+	}
+	switch {
+	case !p.finishedAt.IsZero():
+		pi.Duration = p.finishedAt.Sub(p.startedAt)
+	case !p.startedAt.IsZero():
+		pi.Duration = time.Since(p.startedAt)
+	}
+	return pi
+}
+
+// Phases 按注册顺序返回全部初始化阶段
+func (s *Startup) Phases() []PhaseInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	infos := make([]PhaseInfo, 0, len(s.phases))
+	for _, p := range s.phases {
+		infos = append(infos, p.info())
+	}
+	return infos
+}
+
+// Check 启动完成返回 nil，否则返回未完成 / 失败的阶段
+func (s *Startup) Check() error {
+	var pending, failed []string
+	for _, pi := range s.Phases() {
+		switch pi.State {
+		case healthv1.PhaseState_PHASE_STATE_DONE:
+		case healthv1.PhaseState_PHASE_STATE_FAILED:
+			failed = append(failed, pi.Name+": "+pi.Err.Error())
+		default:
+			pending = append(pending, pi.Name)
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("startup failed: %s", strings.Join(failed, "; "))
+	}
+	if len(pending) > 0 {
+		return errors.Errorf("starting, pending phases: %s", strings.Join(pending, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package metricx
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/wire"
+	"github.com/jeffinity/singularity/buildinfo"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/app_init"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+)
+
+// ProviderSet is metrics providers.
+var ProviderSet = wire.NewSet(NewRegistry)
+
+const (
+	meterName   = "metrics"
+	defaultPath = "/metrics"
+)
+
+// Registry 应用级指标注册中心：
+// - 持有独立的 prometheus.Registry，/metrics 只暴露注册到这里的指标
+// - 所有指标统一附加 conf.Metrics.const_labels 以及 service / pc_id 常量标签
+// - 通过 Meter（otel）或 Register（原生 prometheus collector）声明业务指标
+type Registry struct {
+	namespace string
+	path      string
+
+	reg      *prometheus.Registry
+	wrapped  prometheus.Registerer
+	provider *sdkmetric.MeterProvider
+	meter    metric.Meter
+}
+
+// NewRegistry 根据配置创建指标注册中心，并注册 Go runtime / process / build_info 指标
+func NewRegistry(info app_init.AppInfo, c *conf.Bootstrap, logger log.Logger) (*Registry, func(), error) {
+
+	mc := c.GetMetrics()
+	labels := prometheus.Labels{}
+	for k, v := range mc.GetConstLabels() {
+		labels[k] = v
+	}
+	if info.Name != "" {
+		labels["service"] = info.Name
+	}
+	if info.ID != "" {
+		labels["pc_id"] = string(info.ID)
+	}
+
+	reg := prometheus.NewRegistry()
+	wrapped := prometheus.WrapRegistererWith(labels, reg)
+
+	r := &Registry{
+		namespace: mc.GetNamespace(),
+		path:      mc.GetPath(),
+		reg:       reg,
+		wrapped:   wrapped,
+	}
+	if r.path == "" {
+		r.path = defaultPath
+	}
+
+	if err := r.Register(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		newBuildInfo(r.namespace),
+	); err != nil {
+		return nil, nil, err
+	}
+
+	exporter, err := otelprom.New(
+		otelprom.WithRegisterer(wrapped),
+		otelprom.WithNamespace(r.namespace),
+		otelprom.WithoutScopeInfo(),
+	)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "create prometheus exporter failed:")
+	}
+	r.provider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(exporter))
+	r.meter = r.provider.Meter(meterName)
+
+	mLog := log.NewHelper(log.With(logger, "module", "app_layout/metrics"))
+	cleanup := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		if err := r.provider.Shutdown(ctx); err != nil {
+			mLog.Warnf("shutdown meter provider failed: %v", err)
+		}
+	}
+	return r, cleanup, nil
+}
+
+// Meter 返回 otel Meter，用于声明 counter / histogram 等业务指标
+func (r *Registry) Meter() metric.Meter { return r.meter }
+
+// Namespace 指标名前缀，原生 prometheus collector 可按需使用
+func (r *Registry) Namespace() string { return r.namespace }
+
+// Path /metrics 暴露路径
+func (r *Registry) Path() string { return r.path }
+
+// Register 注册原生 prometheus collector，自动附加常量标签
+func (r *Registry) Register(cs ...prometheus.Collector) error {
+	for _, c := range cs {
+		if err := r.wrapped.Register(c); err != nil {
+			return errors.WithMessage(err, "register prometheus collector failed:")
+		}
+	}
+	return nil
+}
+
+// MustRegister 同 Register，失败时 panic，适用于包级初始化的固定指标
+func (r *Registry) MustRegister(cs ...prometheus.Collector) {
+	r.wrapped.MustRegister(cs...)
+}
+
+// Gatherer 返回底层 prometheus.Gatherer
+func (r *Registry) Gatherer() prometheus.Gatherer { return r.reg }
+
+// Handler /metrics 的 HTTP 处理器
+func (r *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(r.reg, promhttp.HandlerOpts{
+		Registry:      r.reg,
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+func newBuildInfo(namespace string) prometheus.Collector {
+	g := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "build_info",
+		Help:      "Build information of the running binary, value is always 1.",
+	}, []string{"version", "commit_id", "build_time", "build_user", "go_version", "go_arch", "build_os"})
+	g.WithLabelValues(
+		buildinfo.Version,
+		buildinfo.CommitID,
+		buildinfo.BuildTime,
+		buildinfo.BuildUser,
+		buildinfo.GoVersion,
+		buildinfo.GoArch,
+		buildinfo.BuildOS,
+	).Set(1)
+	return g
+}
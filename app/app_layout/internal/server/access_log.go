@@ -0,0 +1,148 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/jeffinity/singularity/kratosx"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+)
+
+// requestFilter 根据 conf.AccessLog 判断请求是否跳过访问日志 / 请求指标：
+// - 命中 exclude_operations / exclude_paths 的请求不记录日志与指标（如 k8s 探针）
+// - 过滤在中间件中进行，只对 proto 定义的路由生效；/metrics 等直接挂载的 handler 不经过中间件，无需排除
+// - 成功请求按 success_sample_rate 采样记录日志
+// - 失败请求无论是否被排除或采样，始终记录一条错误日志
+type requestFilter struct {
+	operations map[string]struct{}
+	paths      map[string]struct{}
+	prefixes   []string
+	sampleRate float64
+}
+
+func newRequestFilter(c *conf.AccessLog) *requestFilter {
+	f := &requestFilter{
+		operations: make(map[string]struct{}),
+		paths:      make(map[string]struct{}),
+		sampleRate: c.GetSuccessSampleRate(),
+	}
+	for _, op := range c.GetExcludeOperations() {
+		f.operations[op] = struct{}{}
+	}
+	for _, p := range c.GetExcludePaths() {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok {
+			f.prefixes = append(f.prefixes, prefix)
+			continue
+		}
+		f.paths[p] = struct{}{}
+	}
+	return f
+}
+
+func (f *requestFilter) excluded(ctx context.Context) bool {
+	info, ok := transport.FromServerContext(ctx)
+	if !ok {
+		return false
+	}
+	if _, ok := f.operations[info.Operation()]; ok {
+		return true
+	}
+	ht, ok := info.(http.Transporter)
+	if !ok {
+		return false
+	}
+	return f.matchPath(ht.Request().URL.Path) || f.matchPath(ht.PathTemplate())
+}
+
+func (f *requestFilter) matchPath(path string) bool {
+	if path == "" {
+		return false
+	}
+	if _, ok := f.paths[path]; ok {
+		return true
+	}
+	for _, prefix := range f.prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *requestFilter) sampled() bool {
+	if f.sampleRate <= 0 || f.sampleRate >= 1 {
+		return true
+	}
+	return rand.Float64() < f.sampleRate
+}
+
+// Metrics 包装请求指标中间件，被排除的请求与流式调用（见 RegisterStream）不计入指标
+func (f *requestFilter) Metrics(m middleware.Middleware) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		measured := m(handler)
+		return func(ctx context.Context, req any) (any, error) {
+			if f.excluded(ctx) || isStream(ctx) {
+				return handler(ctx, req)
+			}
+			return measured(ctx, req)
+		}
+	}
+}
+
+// Logger 包装 kratosx.ServerLogger，被排除或未采样的请求仅在失败时记录
+func (f *requestFilter) Logger(logger log.Logger) middleware.Middleware {
+	full := kratosx.ServerLogger(logger)
+	return func(handler middleware.Handler) middleware.Handler {
+		logged := full(handler)
+		return func(ctx context.Context, req any) (any, error) {
+			if !f.excluded(ctx) && f.sampled() {
+				return logged(ctx, req)
+			}
+
+			startTime := time.Now()
+			reply, err := handler(ctx, req)
+			if err != nil {
+				logFailure(ctx, logger, req, err, time.Since(startTime))
+			}
+			return reply, err
+		}
+	}
+}
+
+func logFailure(ctx context.Context, logger log.Logger, req any, err error, latency time.Duration) {
+	var (
+		code      int32
+		reason    string
+		kind      string
+		operation string
+	)
+	if info, ok := transport.FromServerContext(ctx); ok {
+		kind = info.Kind().String()
+		operation = info.Operation()
+	}
+	if se := errors.FromError(err); se != nil {
+		code = se.Code
+		reason = se.Reason
+	}
+
+	_ = log.WithContext(ctx, logger).Log(log.LevelError,
+		"kind", "server",
+		"component", kind,
+		"operation", operation,
+		"code", code,
+		"reason", reason,
+		"stack", fmt.Sprintf("%+v", err),
+		"latency", latency.Seconds(),
+		"type", "Request Failed",
+		"args", kratosx.TruncateBytes(kratosx.Codec.MustMarshal(req), kratosx.MaxShowBodyLen),
+	)
+}
@@ -0,0 +1,222 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-kratos/kratos/v2/encoding"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+)
+
+// jsonCodec 按 conf.JSON 定制的 HTTP JSON 编解码：
+// - proto.Message 使用 protojson，字段名 / 零值 / 枚举按配置输出
+// - int64_as_string=false 时，将 protojson 输出的 64 位整数字符串改写为数字
+// - 其他类型使用 encoding/json
+type jsonCodec struct {
+	marshal       protojson.MarshalOptions
+	unmarshal     protojson.UnmarshalOptions
+	int64AsNumber bool
+}
+
+func newJSONCodec(c *conf.JSON) *jsonCodec {
+	return &jsonCodec{
+		marshal: protojson.MarshalOptions{
+			UseProtoNames:   c.GetUseProtoNames(),
+			EmitUnpopulated: c == nil || c.EmitUnpopulated == nil || c.GetEmitUnpopulated(),
+			UseEnumNumbers:  c.GetEnumAsNumber(),
+		},
+		unmarshal:     protojson.UnmarshalOptions{DiscardUnknown: true},
+		int64AsNumber: c != nil && c.Int64AsString != nil && !c.GetInt64AsString(),
+	}
+}
+
+func (c *jsonCodec) Name() string {
+	return "json"
+}
+
+func (c *jsonCodec) Marshal(v any) ([]byte, error) {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return json.Marshal(v)
+	}
+	data, err := c.marshal.Marshal(m)
+	if err != nil || !c.int64AsNumber {
+		return data, err
+	}
+	return int64ToNumber(data, m.ProtoReflect().Descriptor(), c.marshal.UseProtoNames)
+}
+
+func (c *jsonCodec) Unmarshal(data []byte, v any) error {
+	if m, ok := v.(proto.Message); ok {
+		return c.unmarshal.Unmarshal(data, m)
+	}
+	return json.Unmarshal(data, v)
+}
+
+// forRequest 与 khttp.CodecForRequest 一致地按请求头选择 codec，命中 json 时替换为 jsonCodec
+func (c *jsonCodec) forRequest(r *http.Request, name string) (encoding.Codec, bool) {
+	codec, ok := khttp.CodecForRequest(r, name)
+	if codec.Name() == c.Name() {
+		return c, ok
+	}
+	return codec, ok
+}
+
+// decodeRequest 同 khttp.DefaultRequestDecoder，JSON 请求体使用 jsonCodec 解码
+func (c *jsonCodec) decodeRequest(r *http.Request, v any) error {
+	codec, ok := c.forRequest(r, "Content-Type")
+	if !ok {
+		return kerrors.BadRequest("CODEC", fmt.Sprintf("unregister Content-Type: %s", r.Header.Get("Content-Type")))
+	}
+	data, err := io.ReadAll(r.Body)
+
+	// reset body.
+	r.Body = io.NopCloser(bytes.NewBuffer(data))
+
+	if err != nil {
+		return kerrors.BadRequest("CODEC", err.Error())
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	if err = codec.Unmarshal(data, v); err != nil {
+		return kerrors.BadRequest("CODEC", fmt.Sprintf("body unmarshal %s", err.Error()))
+	}
+	return nil
+}
+
+// encodeResponse 同 khttp.DefaultResponseEncoder，JSON 响应使用 jsonCodec 编码
+func (c *jsonCodec) encodeResponse(w http.ResponseWriter, r *http.Request, v any) error {
+	if v == nil {
+		return nil
+	}
+	if _, ok := v.(khttp.Redirector); ok {
+		return khttp.DefaultResponseEncoder(w, r, v)
+	}
+	codec, _ := c.forRequest(r, "Accept")
+	data, err := codec.Marshal(v)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/"+codec.Name())
+	_, err = w.Write(data)
+	return err
+}
+
+// encodeError 同 khttp.DefaultErrorEncoder，JSON 响应使用 jsonCodec 编码
+func (c *jsonCodec) encodeError(w http.ResponseWriter, r *http.Request, err error) {
+	se := kerrors.FromError(err)
+	codec, _ := c.forRequest(r, "Accept")
+	body, err := codec.Marshal(se)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/"+codec.Name())
+	w.WriteHeader(int(se.Code))
+	_, _ = w.Write(body)
+}
+
+// int64ToNumber 将 protojson 输出中 64 位整数字段（含 map 值、repeated、Int64Value/UInt64Value）由字符串改写为数字
+func int64ToNumber(data []byte, md protoreflect.MessageDescriptor, useProtoNames bool) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	v = rewriteMessage(v, md, useProtoNames)
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func rewriteMessage(v any, md protoreflect.MessageDescriptor, useProtoNames bool) any {
+	switch md.FullName() {
+	case "google.protobuf.Int64Value", "google.protobuf.UInt64Value":
+		return toNumber(v)
+	}
+	if md.FullName().Parent() == "google.protobuf" {
+		// 其余 WKT（Timestamp/Duration/Struct/Any 等）有专门的 JSON 表示，保持原样
+		return v
+	}
+
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		key := fd.JSONName()
+		if useProtoNames {
+			key = string(fd.Name())
+		}
+		fv, ok := obj[key]
+		if !ok {
+			continue
+		}
+		obj[key] = rewriteField(fv, fd, useProtoNames)
+	}
+	return obj
+}
+
+func rewriteField(v any, fd protoreflect.FieldDescriptor, useProtoNames bool) any {
+	switch {
+	case fd.IsMap():
+		m, ok := v.(map[string]any)
+		if !ok {
+			return v
+		}
+		for k, mv := range m {
+			m[k] = rewriteValue(mv, fd.MapValue(), useProtoNames)
+		}
+		return m
+	case fd.IsList():
+		l, ok := v.([]any)
+		if !ok {
+			return v
+		}
+		for i, lv := range l {
+			l[i] = rewriteValue(lv, fd, useProtoNames)
+		}
+		return l
+	default:
+		return rewriteValue(v, fd, useProtoNames)
+	}
+}
+
+func rewriteValue(v any, fd protoreflect.FieldDescriptor, useProtoNames bool) any {
+	switch fd.Kind() {
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return toNumber(v)
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return rewriteMessage(v, fd.Message(), useProtoNames)
+	default:
+		return v
+	}
+}
+
+func toNumber(v any) any {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	return json.Number(s)
+}
@@ -6,22 +6,21 @@ import (
 
 	validate "github.com/go-kratos/kratos/contrib/middleware/validate/v2"
 	"github.com/go-kratos/kratos/v2/log"
-	"github.com/go-kratos/kratos/v2/middleware/metrics"
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
-	"github.com/jeffinity/singularity/kratosx"
 	ggrpc "google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
 
 	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/service"
 	healthv1 "github.com/jeffinity/app-layout/pkg/health"
+	hellov1 "github.com/jeffinity/app-layout/pkg/hello"
 )
 
 const maxMsgSize = 50 * 1024 * 1024
 
 // NewGRPCServer new a gRPC server.
-func NewGRPCServer(c *conf.Bootstrap, hs *service.HealthService, logger log.Logger) *grpc.Server {
+func NewGRPCServer(c *conf.Bootstrap, sm *ServerMetrics, idem *Idempotency, hs *service.HealthService, hello *service.HelloService, logger log.Logger) *grpc.Server {
 
 	kaep := keepalive.EnforcementPolicy{
 		MinTime:             20 * time.Second,
@@ -34,18 +33,17 @@ func NewGRPCServer(c *conf.Bootstrap, hs *service.HealthService, logger log.Logg
 	}
 
 	mLog := log.NewHelper(logger)
+	rf := newRequestFilter(c.GetServer().GetAccessLog())
 	var opts = []grpc.ServerOption{
 		grpc.Middleware(
 			recovery.Recovery(recovery.WithHandler(func(ctx context.Context, req, err any) error {
 				mLog.Errorf("[Recovery] catch an err: %+v", err)
 				return recovery.ErrUnknownRequest
 			})),
-			metrics.Server(
-				metrics.WithSeconds(_metricSeconds),
-				metrics.WithRequests(_metricRequests),
-			),
+			rf.Metrics(sm.Middleware()),
 			validate.ProtoValidate(),
-			kratosx.ServerLogger(logger),
+			rf.Logger(logger),
+			idem.Middleware(),
 		),
 		grpc.Options(
 			ggrpc.KeepaliveEnforcementPolicy(kaep),
@@ -68,5 +66,6 @@ func NewGRPCServer(c *conf.Bootstrap, hs *service.HealthService, logger log.Logg
 
 	// TODO 为你实际的业务服务，注册 gRPC
 	healthv1.RegisterHealthServiceServer(srv, hs)
+	hellov1.RegisterHelloServiceServer(srv, hello)
 	return srv
 }
@@ -6,21 +6,26 @@ import (
 	"github.com/go-kratos/kratos/contrib/middleware/validate/v2"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/middleware"
-	"github.com/go-kratos/kratos/v2/middleware/metrics"
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
 	"github.com/go-kratos/kratos/v2/transport/http"
-	"github.com/jeffinity/singularity/kratosx"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/jeffinity/singularity/buildinfo"
+	"github.com/jeffinity/singularity/friendly"
 
+	"github.com/jeffinity/app-layout/app/app_layout/internal/app_init"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/metricx"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/server/openapi"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/service"
 	healthv1 "github.com/jeffinity/app-layout/pkg/health"
+	hellov1 "github.com/jeffinity/app-layout/pkg/hello"
 )
 
 // NewHTTPServer new an HTTP server.
-func NewHTTPServer(c *conf.Bootstrap, hs *service.HealthService, logger log.Logger) *http.Server {
+func NewHTTPServer(c *conf.Bootstrap, info app_init.AppInfo, mr *metricx.Registry, sm *ServerMetrics, idem *Idempotency, hs *service.HealthService, hello *service.HelloService, logger log.Logger) *http.Server {
 
 	mLog := log.NewHelper(logger)
+	rf := newRequestFilter(c.GetServer().GetAccessLog())
+	jc := newJSONCodec(c.GetServer().GetHttp().GetJson())
 	var opts = []http.ServerOption{
 		http.Middleware(
 			middleware.Chain(
@@ -28,14 +33,15 @@ func NewHTTPServer(c *conf.Bootstrap, hs *service.HealthService, logger log.Logg
 					mLog.Errorf("[Recovery] catch an err: %+v", err)
 					return recovery.ErrUnknownRequest
 				})),
-				metrics.Server(
-					metrics.WithSeconds(_metricSeconds),
-					metrics.WithRequests(_metricRequests),
-				),
+				rf.Metrics(sm.Middleware()),
 				validate.ProtoValidate(),
-				kratosx.ServerLogger(logger),
+				rf.Logger(logger),
+				idem.Middleware(),
 			),
 		),
+		http.RequestDecoder(jc.decodeRequest),
+		http.ResponseEncoder(jc.encodeResponse),
+		http.ErrorEncoder(jc.encodeError),
 	}
 	if c.GetServer().GetHttp().GetNetwork() != "" {
 		opts = append(opts, http.Network(c.GetServer().GetHttp().Network))
@@ -47,9 +53,35 @@ func NewHTTPServer(c *conf.Bootstrap, hs *service.HealthService, logger log.Logg
 		opts = append(opts, http.Timeout(c.GetServer().GetHttp().Timeout.AsDuration()))
 	}
 	srv := http.NewServer(opts...)
-	srv.Handle("/metrics", promhttp.Handler())
+	srv.Handle(mr.Path(), mr.Handler())
 
-	// TODO 为你实际的业务服务，注册 HTTP
+	// TODO 为你实际的业务服务，注册 HTTP；server-streaming 方法通过 RegisterStream 以 SSE / WebSocket 暴露
 	healthv1.RegisterHealthServiceHTTPServer(srv, hs)
+	RegisterStream(srv, jc, "/apis/health/v1/healthz/watch", healthv1.HealthService_Watch_FullMethodName, hs.Watch)
+	hellov1.RegisterHelloServiceHTTPServer(srv, hello)
+
+	registerOpenAPI(srv, c.GetServer().GetHttp().GetOpenapi(), jc, info, mLog)
 	return srv
 }
+
+func registerOpenAPI(srv *http.Server, oc *conf.OpenAPI, jc *jsonCodec, info app_init.AppInfo, mLog *log.Helper) {
+	if !oc.GetEnabled() {
+		return
+	}
+
+	path := friendly.GetOrDefault(oc.GetPath(), openapi.DefaultPath)
+	docsPath := friendly.GetOrDefault(oc.GetDocsPath(), openapi.DefaultDocsPath)
+	err := openapi.Register(srv, path, docsPath, openapi.Options{
+		Title:   friendly.GetOrDefault(oc.GetTitle(), info.Name),
+		Version: buildinfo.Version,
+		// 与 HTTP JSON 编解码配置保持一致
+		UseProtoNames: jc.marshal.UseProtoNames,
+		EnumAsNumber:  jc.marshal.UseEnumNumbers,
+		Int64AsString: !jc.int64AsNumber,
+	})
+	if err != nil {
+		mLog.Warnf("register openapi failed: %+v", err)
+		return
+	}
+	mLog.Infof("openapi document served at %s, docs at %s", path, docsPath)
+}
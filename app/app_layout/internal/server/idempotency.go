@@ -0,0 +1,180 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/oklog/ulid/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/biz"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	defaultIdempotencyTTL     = 24 * time.Hour
+	defaultIdempotencyLockTTL = 60 * time.Second
+	maxIdempotencyKeyLen      = 128
+)
+
+var (
+	ErrIdempotencyInFlight = errors.Conflict("IDEMPOTENCY_IN_FLIGHT", "a request with the same idempotency key is in progress")
+	ErrIdempotencyMismatch = errors.New(422, "IDEMPOTENCY_KEY_REUSED", "idempotency key was already used for a different request")
+	ErrIdempotencyKeyLen   = errors.BadRequest("IDEMPOTENCY_KEY_INVALID", "idempotency key is too long")
+	ErrIdempotencyKeyMiss  = errors.BadRequest("IDEMPOTENCY_KEY_REQUIRED", "idempotency key is required")
+)
+
+// Idempotency 对 conf.Idempotency 配置的 operation 按 Idempotency-Key 去重：
+// - 首次请求执行业务，成功后保存响应；失败则释放幂等键，允许客户端重试
+// - 重复请求直接回放首次响应，并在响应头设置 Idempotent-Replayed: true
+// - 首次请求仍在处理中时，重复请求返回 409 IDEMPOTENCY_IN_FLIGHT
+// - 同一幂等键用于不同请求体时返回 422 IDEMPOTENCY_KEY_REUSED
+type Idempotency struct {
+	repo       biz.IdempotencyRepo
+	operations map[string]struct{}
+	ttl        time.Duration
+	lockTTL    time.Duration
+	requireKey bool
+	log        *log.Helper
+}
+
+func NewIdempotency(c *conf.Bootstrap, repo biz.IdempotencyRepo, logger log.Logger) *Idempotency {
+	ic := c.GetServer().GetIdempotency()
+	i := &Idempotency{
+		repo:       repo,
+		operations: make(map[string]struct{}),
+		ttl:        defaultIdempotencyTTL,
+		lockTTL:    defaultIdempotencyLockTTL,
+		requireKey: ic.GetRequireKey(),
+		log:        log.NewHelper(log.With(logger, "module", "app_layout/Idempotency")),
+	}
+	for _, op := range ic.GetOperations() {
+		i.operations[op] = struct{}{}
+	}
+	if ic.GetTtl() != nil {
+		i.ttl = ic.GetTtl().AsDuration()
+	}
+	if ic.GetLockTtl() != nil {
+		i.lockTTL = ic.GetLockTtl().AsDuration()
+	}
+	return i
+}
+
+func (i *Idempotency) Middleware() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+			if _, ok := i.operations[tr.Operation()]; !ok {
+				return handler(ctx, req)
+			}
+
+			idemKey := tr.RequestHeader().Get(IdempotencyKeyHeader)
+			if idemKey == "" {
+				if i.requireKey {
+					return nil, ErrIdempotencyKeyMiss
+				}
+				return handler(ctx, req)
+			}
+			if len(idemKey) > maxIdempotencyKeyLen {
+				return nil, ErrIdempotencyKeyLen
+			}
+			return i.serve(ctx, tr, idemKey, req, handler)
+		}
+	}
+}
+
+func (i *Idempotency) serve(ctx context.Context, tr transport.Transporter, idemKey string, req any, handler middleware.Handler) (any, error) {
+	fingerprint, err := requestFingerprint(tr.Operation(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	key := tr.Operation() + ":" + idemKey
+	token := ulid.Make().String()
+	state, rec, err := i.repo.Acquire(ctx, key, token, i.lockTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch state {
+	case biz.IdempotencyInFlight:
+		return nil, ErrIdempotencyInFlight
+	case biz.IdempotencyCompleted:
+		if rec.Fingerprint != fingerprint {
+			return nil, ErrIdempotencyMismatch
+		}
+		reply, err := decodeReply(rec.Reply)
+		if err != nil {
+			return nil, err
+		}
+		tr.ReplyHeader().Set(IdempotencyReplayedHeader, "true")
+		return reply, nil
+	}
+
+	// 业务执行期间客户端断开不应影响结果的保存 / 释放
+	storeCtx := context.WithoutCancel(ctx)
+	reply, err := handler(ctx, req)
+	if err != nil {
+		if rErr := i.repo.Release(storeCtx, key, token); rErr != nil {
+			i.log.WithContext(ctx).Errorf("release idempotency key %s failed: %+v", key, rErr)
+		}
+		return reply, err
+	}
+
+	data, mErr := encodeReply(reply)
+	if mErr == nil {
+		mErr = i.repo.Complete(storeCtx, key, token, &biz.IdempotencyRecord{Fingerprint: fingerprint, Reply: data}, i.ttl)
+	}
+	if mErr != nil {
+		// 业务已成功执行，保存失败只记录日志；释放幂等键以免重试一直被 409 拒绝
+		i.log.WithContext(ctx).Errorf("save idempotency reply %s failed: %+v", key, mErr)
+		_ = i.repo.Release(storeCtx, key, token)
+	}
+	return reply, nil
+}
+
+func requestFingerprint(operation string, req any) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(operation))
+	if m, ok := req.(proto.Message); ok {
+		data, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+		if err != nil {
+			return "", errors.BadRequest("IDEMPOTENCY_FINGERPRINT", err.Error())
+		}
+		h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func encodeReply(reply any) ([]byte, error) {
+	m, ok := reply.(proto.Message)
+	if !ok {
+		return nil, errors.InternalServer("IDEMPOTENCY_REPLY", "reply is not a proto message")
+	}
+	a, err := anypb.New(m)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(a)
+}
+
+func decodeReply(data []byte) (proto.Message, error) {
+	var a anypb.Any
+	if err := proto.Unmarshal(data, &a); err != nil {
+		return nil, err
+	}
+	return a.UnmarshalNew()
+}
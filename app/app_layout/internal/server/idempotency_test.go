@@ -0,0 +1,258 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/app_init"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/data"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/health"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/metricx"
+)
+
+const (
+	testIdempotentOperation = "/app_layout.test.v1.TestService/Create"
+	testOtherOperation      = "/app_layout.test.v1.TestService/Update"
+)
+
+type headerCarrier http.Header
+
+func (h headerCarrier) Get(key string) string      { return http.Header(h).Get(key) }
+func (h headerCarrier) Set(key, value string)      { http.Header(h).Set(key, value) }
+func (h headerCarrier) Add(key, value string)      { http.Header(h).Add(key, value) }
+func (h headerCarrier) Values(key string) []string { return http.Header(h).Values(key) }
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+type testTransport struct {
+	operation   string
+	reqHeader   headerCarrier
+	replyHeader headerCarrier
+}
+
+func (t *testTransport) Kind() transport.Kind            { return transport.KindHTTP }
+func (t *testTransport) Endpoint() string                { return "" }
+func (t *testTransport) Operation() string               { return t.operation }
+func (t *testTransport) RequestHeader() transport.Header { return t.reqHeader }
+func (t *testTransport) ReplyHeader() transport.Header   { return t.replyHeader }
+
+// newTestIdempotency 基于 miniredis 与 data 层的 IdempotencyRepo 构建中间件
+func newTestIdempotency(t *testing.T, ic *conf.Idempotency) *Idempotency {
+	t.Helper()
+	c := &conf.Bootstrap{Server: &conf.Servers{Idempotency: ic}}
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{mr.Addr()}})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	metrics, metricsCleanup, err := metricx.NewRegistry(app_init.AppInfo{}, c, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(metricsCleanup)
+	d, cleanup, err := data.NewData(c, nil, &data.Replicas{}, rdb, health.NewRegistry(c), metrics, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(cleanup)
+	return NewIdempotency(c, data.NewIdempotencyRepo(d, log.DefaultLogger), log.DefaultLogger)
+}
+
+type callResult struct {
+	reply    any
+	err      error
+	replayed bool
+}
+
+func call(m middleware.Middleware, key string, req any, h middleware.Handler) callResult {
+	tr := &testTransport{
+		operation:   testIdempotentOperation,
+		reqHeader:   headerCarrier{},
+		replyHeader: headerCarrier{},
+	}
+	if key != "" {
+		tr.reqHeader.Set(IdempotencyKeyHeader, key)
+	}
+	ctx := transport.NewServerContext(context.Background(), tr)
+	reply, err := m(h)(ctx, req)
+	return callResult{reply: reply, err: err, replayed: tr.replyHeader.Get(IdempotencyReplayedHeader) == "true"}
+}
+
+// countingHandler 返回请求值与调用序号拼接的响应，并记录调用次数
+func countingHandler(calls *atomic.Int32) middleware.Handler {
+	return func(_ context.Context, req any) (any, error) {
+		n := calls.Add(1)
+		return wrapperspb.String(req.(*wrapperspb.StringValue).GetValue() + "-" + strconv.Itoa(int(n))), nil
+	}
+}
+
+func assertKratosError(t *testing.T, err error, code int, reason string) {
+	t.Helper()
+	if kerrors.Code(err) != code || kerrors.Reason(err) != reason {
+		t.Fatalf("got %v, want %d %s", err, code, reason)
+	}
+}
+
+func TestIdempotencyReplay(t *testing.T) {
+	m := newTestIdempotency(t, &conf.Idempotency{Operations: []string{testIdempotentOperation}}).Middleware()
+	var calls atomic.Int32
+	req := wrapperspb.String("alice")
+
+	first := call(m, "k1", req, countingHandler(&calls))
+	if first.err != nil {
+		t.Fatal(first.err)
+	}
+	if first.replayed {
+		t.Fatal("first response should not be marked as replayed")
+	}
+
+	second := call(m, "k1", proto.Clone(req), countingHandler(&calls))
+	if second.err != nil {
+		t.Fatal(second.err)
+	}
+	if !second.replayed {
+		t.Fatalf("replayed response should carry %s: true", IdempotencyReplayedHeader)
+	}
+	if !proto.Equal(first.reply.(proto.Message), second.reply.(proto.Message)) {
+		t.Fatalf("replayed %v, want %v", second.reply, first.reply)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("handler called %d times, want 1", n)
+	}
+
+	// 不同幂等键各自执行
+	if res := call(m, "k2", req, countingHandler(&calls)); res.err != nil || res.replayed {
+		t.Fatalf("got %+v for a new key", res)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("handler called %d times, want 2", n)
+	}
+}
+
+func TestIdempotencyInFlight(t *testing.T) {
+	m := newTestIdempotency(t, &conf.Idempotency{Operations: []string{testIdempotentOperation}}).Middleware()
+	req := wrapperspb.String("alice")
+
+	started, release := make(chan struct{}), make(chan struct{})
+	done := make(chan callResult)
+	go func() {
+		done <- call(m, "k1", req, func(context.Context, any) (any, error) {
+			close(started)
+			<-release
+			return wrapperspb.String("alice"), nil
+		})
+	}()
+	<-started
+
+	var calls atomic.Int32
+	res := call(m, "k1", req, countingHandler(&calls))
+	assertKratosError(t, res.err, 409, "IDEMPOTENCY_IN_FLIGHT")
+	if calls.Load() != 0 {
+		t.Fatal("handler should not run while the first request is in flight")
+	}
+
+	close(release)
+	if first := <-done; first.err != nil {
+		t.Fatal(first.err)
+	}
+	// 首次请求完成后回放
+	if res := call(m, "k1", req, countingHandler(&calls)); res.err != nil || !res.replayed {
+		t.Fatalf("got %+v after the first request completed, want replay", res)
+	}
+}
+
+func TestIdempotencyFingerprintMismatch(t *testing.T) {
+	m := newTestIdempotency(t, &conf.Idempotency{Operations: []string{testIdempotentOperation}}).Middleware()
+	var calls atomic.Int32
+
+	if res := call(m, "k1", wrapperspb.String("alice"), countingHandler(&calls)); res.err != nil {
+		t.Fatal(res.err)
+	}
+	res := call(m, "k1", wrapperspb.String("bob"), countingHandler(&calls))
+	assertKratosError(t, res.err, 422, "IDEMPOTENCY_KEY_REUSED")
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("handler called %d times, want 1", n)
+	}
+}
+
+func TestIdempotencyReleaseOnError(t *testing.T) {
+	m := newTestIdempotency(t, &conf.Idempotency{Operations: []string{testIdempotentOperation}}).Middleware()
+	req := wrapperspb.String("alice")
+	errHandler := errors.New("handler failed")
+
+	res := call(m, "k1", req, func(context.Context, any) (any, error) { return nil, errHandler })
+	if !errors.Is(res.err, errHandler) {
+		t.Fatalf("got %v, want %v", res.err, errHandler)
+	}
+
+	// 失败后幂等键已释放，重试重新执行
+	var calls atomic.Int32
+	if res := call(m, "k1", req, countingHandler(&calls)); res.err != nil || res.replayed {
+		t.Fatalf("got %+v for the retry, want a fresh execution", res)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("handler called %d times, want 1", n)
+	}
+}
+
+func TestIdempotencyKeyValidation(t *testing.T) {
+	var calls atomic.Int32
+	req := wrapperspb.String("alice")
+
+	m := newTestIdempotency(t, &conf.Idempotency{Operations: []string{testIdempotentOperation}}).Middleware()
+	// 默认未携带幂等键时放行，每次都执行
+	for range 2 {
+		if res := call(m, "", req, countingHandler(&calls)); res.err != nil || res.replayed {
+			t.Fatalf("got %+v without idempotency key", res)
+		}
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("handler called %d times, want 2", n)
+	}
+	assertKratosError(t, call(m, strings.Repeat("k", maxIdempotencyKeyLen+1), req, countingHandler(&calls)).err,
+		400, "IDEMPOTENCY_KEY_INVALID")
+
+	required := newTestIdempotency(t, &conf.Idempotency{
+		Operations: []string{testIdempotentOperation},
+		RequireKey: true,
+	}).Middleware()
+	assertKratosError(t, call(required, "", req, countingHandler(&calls)).err, 400, "IDEMPOTENCY_KEY_REQUIRED")
+}
+
+func TestIdempotencyOtherOperation(t *testing.T) {
+	m := newTestIdempotency(t, &conf.Idempotency{
+		Operations: []string{testOtherOperation},
+		RequireKey: true,
+	}).Middleware()
+	var calls atomic.Int32
+	req := wrapperspb.String("alice")
+
+	// 未配置的 operation 不做幂等处理
+	for range 2 {
+		if res := call(m, "k1", req, countingHandler(&calls)); res.err != nil || res.replayed {
+			t.Fatalf("got %+v for an operation without idempotency", res)
+		}
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("handler called %d times, want 2", n)
+	}
+}
@@ -0,0 +1,373 @@
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	version      = "3.0.3"
+	refPrefix    = "#/components/schemas/"
+	contentJSON  = "application/json"
+	contentSSE   = "text/event-stream"
+	maxQueryDeep = 3
+)
+
+var pathVarRe = regexp.MustCompile(`\{([^}=]+)(=[^}]*)?\}`)
+
+// Options 生成选项，字段命名 / 枚举 / int64 的表示需与 HTTP codec 的 JSON 输出保持一致
+type Options struct {
+	Title         string
+	Version       string
+	UseProtoNames bool // 字段名使用 proto 原始名，否则使用 lowerCamelCase
+	EnumAsNumber  bool // 枚举使用数字，否则使用名称
+	Int64AsString bool // 64 位整数使用字符串（protojson 默认行为）
+}
+
+type generator struct {
+	opts Options
+	doc  *Document
+}
+
+// Generate 遍历 protoregistry.GlobalFiles 中已注册的服务，
+// 为所有带 google.api.http 注解的方法生成 OpenAPI v3 文档
+func Generate(opts Options) *Document {
+	g := &generator{
+		opts: opts,
+		doc: &Document{
+			OpenAPI:    version,
+			Info:       Info{Title: opts.Title, Version: opts.Version},
+			Paths:      map[string]*PathItem{},
+			Components: Components{Schemas: map[string]*Schema{}},
+		},
+	}
+
+	var services []protoreflect.ServiceDescriptor
+	protoregistry.GlobalFiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		for i := 0; i < fd.Services().Len(); i++ {
+			services = append(services, fd.Services().Get(i))
+		}
+		return true
+	})
+	sort.Slice(services, func(i, j int) bool { return services[i].FullName() < services[j].FullName() })
+
+	for _, sd := range services {
+		if g.addService(sd) {
+			g.doc.Tags = append(g.doc.Tags, Tag{Name: string(sd.FullName())})
+		}
+	}
+	return g.doc
+}
+
+func (g *generator) addService(sd protoreflect.ServiceDescriptor) bool {
+	added := false
+	for i := 0; i < sd.Methods().Len(); i++ {
+		md := sd.Methods().Get(i)
+		rule := httpRule(md)
+		if rule == nil || md.IsStreamingClient() {
+			continue
+		}
+		bindings := append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...)
+		for idx, b := range bindings {
+			if g.addOperation(sd, md, b, idx) {
+				added = true
+			}
+		}
+	}
+	return added
+}
+
+func httpRule(md protoreflect.MethodDescriptor) *annotations.HttpRule {
+	opts := md.Options()
+	if opts == nil || !proto.HasExtension(opts, annotations.E_Http) {
+		return nil
+	}
+	rule, ok := proto.GetExtension(opts, annotations.E_Http).(*annotations.HttpRule)
+	if !ok {
+		return nil
+	}
+	return rule
+}
+
+func rulePattern(rule *annotations.HttpRule) (string, string) {
+	switch p := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return "GET", p.Get
+	case *annotations.HttpRule_Put:
+		return "PUT", p.Put
+	case *annotations.HttpRule_Post:
+		return "POST", p.Post
+	case *annotations.HttpRule_Delete:
+		return "DELETE", p.Delete
+	case *annotations.HttpRule_Patch:
+		return "PATCH", p.Patch
+	case *annotations.HttpRule_Custom:
+		return strings.ToUpper(p.Custom.GetKind()), p.Custom.GetPath()
+	}
+	return "", ""
+}
+
+// convertPath 将 /v1/{name=messages/*} 转换为 /v1/{name}，并返回路径变量
+func convertPath(tmpl string) (string, []string) {
+	var vars []string
+	path := pathVarRe.ReplaceAllStringFunc(tmpl, func(s string) string {
+		name := pathVarRe.FindStringSubmatch(s)[1]
+		vars = append(vars, name)
+		return "{" + name + "}"
+	})
+	return path, vars
+}
+
+func (g *generator) addOperation(sd protoreflect.ServiceDescriptor, md protoreflect.MethodDescriptor, rule *annotations.HttpRule, idx int) bool {
+	method, tmpl := rulePattern(rule)
+	if method == "" || tmpl == "" {
+		return false
+	}
+	path, vars := convertPath(tmpl)
+
+	op := &Operation{
+		OperationID: fmt.Sprintf("%s_%s", sd.Name(), md.Name()),
+		Summary:     string(md.Name()),
+		Tags:        []string{string(sd.FullName())},
+		Responses:   map[string]*Response{},
+	}
+	if idx > 0 {
+		op.OperationID = fmt.Sprintf("%s_%d", op.OperationID, idx)
+	}
+
+	in := md.Input()
+	bound := map[string]bool{}
+	for _, v := range vars {
+		bound[v] = true
+		fd := findField(in, v)
+		if fd == nil {
+			continue
+		}
+		op.Parameters = append(op.Parameters, &Parameter{Name: v, In: "path", Required: true, Schema: g.fieldSchema(fd)})
+	}
+
+	switch body := rule.GetBody(); body {
+	case "":
+		op.Parameters = append(op.Parameters, g.queryParams(in, "", "", bound, 0)...)
+	case "*":
+		op.RequestBody = &RequestBody{Required: true, Content: jsonContent(g.messageRef(in))}
+	default:
+		bound[body] = true
+		if fd := in.Fields().ByName(protoreflect.Name(body)); fd != nil {
+			op.RequestBody = &RequestBody{Required: true, Content: jsonContent(g.fieldSchema(fd))}
+		}
+		op.Parameters = append(op.Parameters, g.queryParams(in, "", "", bound, 0)...)
+	}
+
+	reply := g.messageRef(md.Output())
+	if rb := rule.GetResponseBody(); rb != "" {
+		if fd := md.Output().Fields().ByName(protoreflect.Name(rb)); fd != nil {
+			reply = g.fieldSchema(fd)
+		}
+	}
+	okResp := &Response{Description: "OK", Content: jsonContent(reply)}
+	if md.IsStreamingServer() {
+		okResp = &Response{Description: "Server-Sent Events stream", Content: map[string]*MediaType{contentSSE: {Schema: reply}}}
+	}
+	op.Responses["200"] = okResp
+	op.Responses["default"] = &Response{
+		Description: "Error",
+		Content:     jsonContent(g.messageRef((&kerrors.Status{}).ProtoReflect().Descriptor())),
+	}
+
+	item, ok := g.doc.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		g.doc.Paths[path] = item
+	}
+	item.set(method, op)
+	return true
+}
+
+func jsonContent(s *Schema) map[string]*MediaType {
+	return map[string]*MediaType{contentJSON: {Schema: s}}
+}
+
+func findField(md protoreflect.MessageDescriptor, path string) protoreflect.FieldDescriptor {
+	var fd protoreflect.FieldDescriptor
+	for _, name := range strings.Split(path, ".") {
+		if md == nil {
+			return nil
+		}
+		fd = md.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return nil
+		}
+		md = fd.Message()
+	}
+	return fd
+}
+
+// queryParams 未绑定到路径与 body 的字段作为 query 参数，嵌套消息以 a.b 形式展开
+func (g *generator) queryParams(md protoreflect.MessageDescriptor, protoPrefix, namePrefix string, bound map[string]bool, depth int) []*Parameter {
+	var params []*Parameter
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		protoPath := protoPrefix + string(fd.Name())
+		name := namePrefix + g.fieldName(fd)
+		if bound[protoPath] || fd.IsMap() {
+			continue
+		}
+		if fd.Kind() == protoreflect.MessageKind && wktSchema(g, fd.Message()) == nil {
+			if fd.IsList() || depth >= maxQueryDeep {
+				continue
+			}
+			params = append(params, g.queryParams(fd.Message(), protoPath+".", name+".", bound, depth+1)...)
+			continue
+		}
+		params = append(params, &Parameter{Name: name, In: "query", Schema: g.fieldSchema(fd)})
+	}
+	return params
+}
+
+func (g *generator) fieldName(fd protoreflect.FieldDescriptor) string {
+	if g.opts.UseProtoNames {
+		return string(fd.Name())
+	}
+	return fd.JSONName()
+}
+
+func (g *generator) messageRef(md protoreflect.MessageDescriptor) *Schema {
+	if s := wktSchema(g, md); s != nil {
+		return s
+	}
+	name := string(md.FullName())
+	if _, ok := g.doc.Components.Schemas[name]; !ok {
+		// 先占位，避免递归消息无限展开
+		g.doc.Components.Schemas[name] = &Schema{}
+		g.doc.Components.Schemas[name] = g.messageSchema(md)
+	}
+	return &Schema{Ref: refPrefix + name}
+}
+
+func (g *generator) messageSchema(md protoreflect.MessageDescriptor) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := g.fieldName(fd)
+		s.Properties[name] = g.fieldSchema(fd)
+		if isRequired(fd) {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+func (g *generator) fieldSchema(fd protoreflect.FieldDescriptor) *Schema {
+	var s *Schema
+	switch {
+	case fd.IsMap():
+		s = &Schema{Type: "object", AdditionalProperties: g.singularSchema(fd.MapValue())}
+	case fd.IsList():
+		s = &Schema{Type: "array", Items: g.singularSchema(fd)}
+	default:
+		s = g.singularSchema(fd)
+	}
+	return applyRules(s, fd)
+}
+
+func (g *generator) singularSchema(fd protoreflect.FieldDescriptor) *Schema {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return &Schema{Type: "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return &Schema{Type: "integer", Format: "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &Schema{Type: "integer", Format: "int64"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return g.int64Schema("int64")
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return g.int64Schema("uint64")
+	case protoreflect.FloatKind:
+		return &Schema{Type: "number", Format: "float"}
+	case protoreflect.DoubleKind:
+		return &Schema{Type: "number", Format: "double"}
+	case protoreflect.StringKind:
+		return &Schema{Type: "string"}
+	case protoreflect.BytesKind:
+		return &Schema{Type: "string", Format: "byte"}
+	case protoreflect.EnumKind:
+		return g.enumRef(fd.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return g.messageRef(fd.Message())
+	}
+	return &Schema{}
+}
+
+func (g *generator) int64Schema(format string) *Schema {
+	if g.opts.Int64AsString {
+		return &Schema{Type: "string", Format: format}
+	}
+	return &Schema{Type: "integer", Format: format}
+}
+
+func (g *generator) enumRef(ed protoreflect.EnumDescriptor) *Schema {
+	name := string(ed.FullName())
+	if _, ok := g.doc.Components.Schemas[name]; !ok {
+		s := &Schema{Type: "string"}
+		if g.opts.EnumAsNumber {
+			s.Type = "integer"
+			s.Format = "int32"
+		}
+		values := ed.Values()
+		for i := 0; i < values.Len(); i++ {
+			v := values.Get(i)
+			if g.opts.EnumAsNumber {
+				s.Enum = append(s.Enum, int32(v.Number()))
+			} else {
+				s.Enum = append(s.Enum, string(v.Name()))
+			}
+		}
+		g.doc.Components.Schemas[name] = s
+	}
+	return &Schema{Ref: refPrefix + name}
+}
+
+// wktSchema well-known types 按 protojson 的映射规则内联，非 WKT 返回 nil
+func wktSchema(g *generator, md protoreflect.MessageDescriptor) *Schema {
+	switch md.FullName() {
+	case "google.protobuf.Timestamp":
+		return &Schema{Type: "string", Format: "date-time"}
+	case "google.protobuf.Duration":
+		return &Schema{Type: "string", Format: "duration"}
+	case "google.protobuf.FieldMask":
+		return &Schema{Type: "string"}
+	case "google.protobuf.Empty":
+		return &Schema{Type: "object"}
+	case "google.protobuf.Struct":
+		return &Schema{Type: "object", AdditionalProperties: &Schema{}}
+	case "google.protobuf.Value":
+		return &Schema{}
+	case "google.protobuf.ListValue":
+		return &Schema{Type: "array", Items: &Schema{}}
+	case "google.protobuf.Any":
+		return &Schema{
+			Type:                 "object",
+			Properties:           map[string]*Schema{"@type": {Type: "string"}},
+			AdditionalProperties: &Schema{},
+		}
+	case "google.protobuf.DoubleValue", "google.protobuf.FloatValue",
+		"google.protobuf.Int64Value", "google.protobuf.UInt64Value",
+		"google.protobuf.Int32Value", "google.protobuf.UInt32Value",
+		"google.protobuf.BoolValue", "google.protobuf.StringValue", "google.protobuf.BytesValue":
+		s := g.singularSchema(md.Fields().ByName("value"))
+		s.Nullable = true
+		return s
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package openapi
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"html/template"
+	"net/http"
+
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultPath     = "/openapi.json"
+	DefaultDocsPath = "/docs"
+)
+
+//go:embed docs.html
+var docsHTML string
+
+var docsTmpl = template.Must(template.New("docs").Parse(docsHTML))
+
+// Register 生成 OpenAPI 文档并挂载到 HTTP server：
+// - path 返回 OpenAPI v3 JSON
+// - docsPath 返回内嵌在二进制中的文档页面（无外部依赖）
+func Register(srv *khttp.Server, path, docsPath string, opts Options) error {
+
+	spec, err := json.Marshal(Generate(opts))
+	if err != nil {
+		return errors.WithMessage(err, "marshal openapi document failed:")
+	}
+
+	var page bytes.Buffer
+	if err := docsTmpl.Execute(&page, map[string]string{"Title": opts.Title, "SpecPath": path}); err != nil {
+		return errors.WithMessage(err, "render docs page failed:")
+	}
+
+	srv.HandleFunc(path, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = w.Write(spec)
+	})
+	srv.HandleFunc(docsPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(page.Bytes())
+	})
+	return nil
+}
@@ -0,0 +1,184 @@
+package openapi
+
+import (
+	"strconv"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// 将 buf.validate（protovalidate，与 validate.ProtoValidate 中间件一致）的字段规则映射为 schema 约束
+
+var stringFormats = map[protoreflect.Name]string{
+	"email":    "email",
+	"uuid":     "uuid",
+	"uri":      "uri",
+	"hostname": "hostname",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"ip":       "ip",
+	"address":  "address",
+}
+
+func fieldRules(fd protoreflect.FieldDescriptor) *validate.FieldRules {
+	opts := fd.Options()
+	if opts == nil || !proto.HasExtension(opts, validate.E_Field) {
+		return nil
+	}
+	rules, ok := proto.GetExtension(opts, validate.E_Field).(*validate.FieldRules)
+	if !ok {
+		return nil
+	}
+	return rules
+}
+
+func isRequired(fd protoreflect.FieldDescriptor) bool {
+	return fieldRules(fd).GetRequired()
+}
+
+func applyRules(s *Schema, fd protoreflect.FieldDescriptor) *Schema {
+	rules := fieldRules(fd)
+	if rules == nil || s.Ref != "" {
+		return s
+	}
+
+	tr := typeRules(rules)
+	if tr == nil {
+		return s
+	}
+	applyTypeRules(s, tr)
+
+	// repeated / map 的元素规则
+	switch {
+	case fd.IsList() && s.Items != nil && s.Items.Ref == "":
+		if items := rules.GetRepeated().GetItems(); items != nil {
+			if itr := typeRules(items); itr != nil {
+				applyTypeRules(s.Items, itr)
+			}
+		}
+	case fd.IsMap() && s.AdditionalProperties != nil && s.AdditionalProperties.Ref == "":
+		if values := rules.GetMap().GetValues(); values != nil {
+			if vtr := typeRules(values); vtr != nil {
+				applyTypeRules(s.AdditionalProperties, vtr)
+			}
+		}
+	}
+	return s
+}
+
+// typeRules 返回 FieldRules.type oneof 中设置的具体规则消息，如 StringRules / Int32Rules
+func typeRules(rules *validate.FieldRules) protoreflect.Message {
+	m := rules.ProtoReflect()
+	od := m.Descriptor().Oneofs().ByName("type")
+	if od == nil {
+		return nil
+	}
+	fd := m.WhichOneof(od)
+	if fd == nil || fd.Kind() != protoreflect.MessageKind {
+		return nil
+	}
+	return m.Get(fd).Message()
+}
+
+func applyTypeRules(s *Schema, m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		name := fd.Name()
+		switch name {
+		case "const":
+			if x, ok := enumValue(s, fd, v); ok {
+				s.Enum = []any{x}
+			}
+		case "in":
+			if fd.IsList() {
+				for i := 0; i < v.List().Len(); i++ {
+					if x, ok := enumValue(s, fd, v.List().Get(i)); ok {
+						s.Enum = append(s.Enum, x)
+					}
+				}
+			}
+		case "gt", "gte":
+			// int64 以字符串表示时 minimum / maximum 对 string 无意义，不输出
+			if f, ok := number(fd, v); ok && isNumeric(s) {
+				s.Minimum, s.ExclusiveMinimum = &f, name == "gt"
+			}
+		case "lt", "lte":
+			if f, ok := number(fd, v); ok && isNumeric(s) {
+				s.Maximum, s.ExclusiveMaximum = &f, name == "lt"
+			}
+		case "len":
+			n := v.Uint()
+			s.MinLength, s.MaxLength = &n, &n
+		case "min_len":
+			n := v.Uint()
+			s.MinLength = &n
+		case "max_len":
+			n := v.Uint()
+			s.MaxLength = &n
+		case "pattern":
+			s.Pattern = v.String()
+		case "min_items":
+			n := v.Uint()
+			s.MinItems = &n
+		case "max_items":
+			n := v.Uint()
+			s.MaxItems = &n
+		case "unique":
+			s.UniqueItems = v.Bool()
+		case "min_pairs":
+			n := v.Uint()
+			s.MinProperties = &n
+		case "max_pairs":
+			n := v.Uint()
+			s.MaxProperties = &n
+		default:
+			if format, ok := stringFormats[name]; ok && fd.Kind() == protoreflect.BoolKind && v.Bool() {
+				s.Format = format
+			}
+		}
+		return true
+	})
+}
+
+func isNumeric(s *Schema) bool {
+	return s.Type == "integer" || s.Type == "number"
+}
+
+// enumValue const / in 的取值；int64 以字符串表示时取值同样输出为字符串，与 schema 类型一致
+func enumValue(s *Schema, fd protoreflect.FieldDescriptor, v protoreflect.Value) (any, bool) {
+	if s.Type == "string" {
+		switch fd.Kind() {
+		case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+			return strconv.FormatInt(v.Int(), 10), true
+		case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+			return strconv.FormatUint(v.Uint(), 10), true
+		}
+	}
+	return scalar(fd, v)
+}
+
+func number(fd protoreflect.FieldDescriptor, v protoreflect.Value) (float64, bool) {
+	switch fd.Kind() {
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float(), true
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return float64(v.Int()), true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return float64(v.Uint()), true
+	}
+	// duration / timestamp 等消息类型的比较规则无法表达为 schema 约束
+	return 0, false
+}
+
+func scalar(fd protoreflect.FieldDescriptor, v protoreflect.Value) (any, bool) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return v.String(), true
+	case protoreflect.BoolKind:
+		return v.Bool(), true
+	case protoreflect.EnumKind:
+		return int32(v.Enum()), true
+	}
+	return number(fd, v)
+}
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/jeffinity/singularity/nacosx"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/health"
+)
+
+const (
+	defaultGateDownAfter = 30 * time.Second
+	defaultGateUpAfter   = 10 * time.Second
+
+	gateCheckInterval = time.Second // 读取缓存的检查结果，开销很小
+)
+
+// ReadinessRegistrar 包装 Nacos 注册，使注册状态跟随 Readiness：
+// - 启动时（kratos app Register）立即注册，与未包装时一致
+// - 已注册时 Readiness 持续失败 down_after 后注销实例，不再接收流量
+// - 注销后 Readiness 持续成功 up_after 后重新注册
+//
+// 两个阈值构成滞回区间，检查结果在阈值内的抖动不会引起反复注册 / 注销。
+// 退出时（kratos app Deregister）先停止跟随，再注销仍处于注册状态的实例。
+type ReadinessRegistrar struct {
+	reg       registry.Registrar
+	startup   *health.Startup
+	prober    *health.Prober
+	disabled  bool
+	downAfter time.Duration
+	upAfter   time.Duration
+	log       *log.Helper
+
+	mu         sync.Mutex
+	registered bool
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewReadinessRegistrar 未配置 Nacos 时返回 nil
+func NewReadinessRegistrar(c *conf.Bootstrap, nr *nacosx.Registry, startup *health.Startup, prober *health.Prober, logger log.Logger) *ReadinessRegistrar {
+	if nr == nil {
+		return nil
+	}
+
+	gc := c.GetNacos().GetReadinessGate()
+	r := &ReadinessRegistrar{
+		reg:       nr,
+		startup:   startup,
+		prober:    prober,
+		disabled:  gc.GetDisabled(),
+		downAfter: defaultGateDownAfter,
+		upAfter:   defaultGateUpAfter,
+		log:       log.NewHelper(log.With(logger, "module", "app_layout/server.ReadinessRegistrar")),
+	}
+	if gc.GetDownAfter() != nil {
+		r.downAfter = gc.GetDownAfter().AsDuration()
+	}
+	if gc.GetUpAfter() != nil {
+		r.upAfter = gc.GetUpAfter().AsDuration()
+	}
+	return r
+}
+
+func (r *ReadinessRegistrar) Register(ctx context.Context, si *registry.ServiceInstance) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.reg.Register(ctx, si); err != nil {
+		return err
+	}
+	r.registered = true
+	if r.disabled || r.cancel != nil {
+		return nil
+	}
+
+	gctx, cancel := context.WithCancel(context.Background())
+	r.cancel, r.done = cancel, make(chan struct{})
+	go r.follow(gctx, si, r.done)
+	r.log.Infof("registered %s, following readiness, down after: %s, up after: %s", si.Name, r.downAfter, r.upAfter)
+	return nil
+}
+
+func (r *ReadinessRegistrar) Deregister(ctx context.Context, si *registry.ServiceInstance) error {
+	// follow 的 apply 需要持锁，等待其退出时不能持有 r.mu；置空后再次 Register 会重新启动跟随
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.cancel, r.done = nil, nil
+	r.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.registered {
+		return nil
+	}
+	if err := r.reg.Deregister(ctx, si); err != nil {
+		return err
+	}
+	r.registered = false
+	return nil
+}
+
+// follow 按 gateCheckInterval 读取 Readiness，结果持续超过阈值时注销 / 重新注册
+func (r *ReadinessRegistrar) follow(ctx context.Context, si *registry.ServiceInstance, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(gateCheckInterval)
+	defer ticker.Stop()
+
+	ready, since := true, time.Now() // 当前 Readiness 结果及其持续起点
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := health.Ready(r.startup, r.prober.Snapshot())
+		now := time.Now()
+		if (err == nil) != ready {
+			ready, since = err == nil, now
+		}
+		r.apply(ctx, si, ready, now.Sub(since), err)
+	}
+}
+
+func (r *ReadinessRegistrar) apply(ctx context.Context, si *registry.ServiceInstance, ready bool, lasting time.Duration, reason error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch {
+	case r.registered && !ready && lasting >= r.downAfter:
+		if err := r.reg.Deregister(ctx, si); err != nil {
+			r.log.Errorf("deregister %s failed, will retry: %+v", si.Name, err)
+			return
+		}
+		r.registered = false
+		r.log.Warnf("deregistered %s, not ready for %s: %v", si.Name, lasting.Truncate(time.Second), reason)
+	case !r.registered && ready && lasting >= r.upAfter:
+		if err := r.reg.Register(ctx, si); err != nil {
+			r.log.Errorf("re-register %s failed, will retry: %+v", si.Name, err)
+			return
+		}
+		r.registered = true
+		r.log.Infof("re-registered %s, ready for %s", si.Name, lasting.Truncate(time.Second))
+	}
+}
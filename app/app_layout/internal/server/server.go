@@ -1,39 +1,40 @@
 package server
 
 import (
+	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/middleware/metrics"
 	"github.com/google/wire"
-	"go.opentelemetry.io/otel/exporters/prometheus"
+	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel/metric"
-	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/metricx"
 )
 
 // ProviderSet is server providers.
-var ProviderSet = wire.NewSet(NewGRPCServer, NewHTTPServer)
-
-var (
-	Name = "metrics"
+var ProviderSet = wire.NewSet(NewServerMetrics, NewIdempotency, NewGRPCServer, NewHTTPServer, NewReadinessRegistrar)
 
-	exporter        *prometheus.Exporter
-	_metricRequests metric.Int64Counter
-	_metricSeconds  metric.Float64Histogram
-)
+// ServerMetrics 请求级指标（请求数 / 耗时），gRPC 与 HTTP 共用
+type ServerMetrics struct {
+	requests metric.Int64Counter
+	seconds  metric.Float64Histogram
+}
 
-func init() {
-	var err error
-	exporter, err = prometheus.New()
+func NewServerMetrics(mr *metricx.Registry) (*ServerMetrics, error) {
+	requests, err := metrics.DefaultRequestsCounter(mr.Meter(), metrics.DefaultServerRequestsCounterName)
 	if err != nil {
-		panic(err)
-	}
-	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(exporter))
-	meter := provider.Meter(Name)
-	_metricRequests, err = metrics.DefaultRequestsCounter(meter, metrics.DefaultServerRequestsCounterName)
-	if err != nil {
-		panic(err)
+		return nil, errors.WithStack(err)
 	}
 
-	_metricSeconds, err = metrics.DefaultSecondsHistogram(meter, metrics.DefaultServerSecondsHistogramName)
+	seconds, err := metrics.DefaultSecondsHistogram(mr.Meter(), metrics.DefaultServerSecondsHistogramName)
 	if err != nil {
-		panic(err)
+		return nil, errors.WithStack(err)
 	}
+	return &ServerMetrics{requests: requests, seconds: seconds}, nil
+}
+
+func (m *ServerMetrics) Middleware() middleware.Middleware {
+	return metrics.Server(
+		metrics.WithSeconds(m.seconds),
+		metrics.WithRequests(m.requests),
+	)
 }
@@ -0,0 +1,349 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/encoding"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	streamHeartbeat    = 15 * time.Second // SSE 注释帧 / WebSocket ping 间隔，用于保活与探测断连
+	streamWriteTimeout = 10 * time.Second // WebSocket 单帧写超时
+	wsCloseReasonLimit = 123              // WebSocket close frame reason 最大字节数
+)
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 4096,
+}
+
+// RegisterStream 将 server-streaming RPC 以 GET 路由暴露到 HTTP server：
+// - 默认以 SSE（text/event-stream）推送，每条消息一个 data 帧
+// - 请求携带 Upgrade: websocket 时升级为 WebSocket，每条消息一个 text 帧
+//
+// 请求参数从 query 与 path 变量绑定；整条流作为一次调用经过 server 的 middleware 链（鉴权、校验、访问日志），与 unary 路由一致。
+// 流不计入请求指标：一条流可持续数小时，计入请求耗时直方图会扭曲 unary 请求的延迟分布，流的失败仍由访问日志记录。
+// codec 为消息编码方式，通常传入 NewHTTPServer 中按 conf.JSON 构建的 jsonCodec，保证与 unary 响应格式一致。
+// protoc-gen-go-http 不为 streaming 方法生成路由，方法上的 google.api.http 注解仅供 OpenAPI 文档使用，path 需与之一致。
+// h 与 protoc-gen-go-grpc 生成的服务端方法签名相同，例如：
+//
+//	RegisterStream(srv, jc, "/apis/foo/v1/watch", foov1.FooService_Watch_FullMethodName, fs.Watch)
+func RegisterStream[Req, Reply any](srv *khttp.Server, codec encoding.Codec, path, operation string, h func(*Req, ggrpc.ServerStreamingServer[Reply]) error) {
+	srv.Route("/").GET(path, func(ctx khttp.Context) error {
+		in := new(Req)
+		if err := ctx.BindQuery(in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(in); err != nil {
+			return err
+		}
+		khttp.SetOperation(ctx, operation)
+
+		sw := newStreamWriter(ctx, codec)
+		handler := ctx.Middleware(func(mctx context.Context, req any) (any, error) {
+			// http server 为每个路由附加了 timeout，长连接需脱离该超时，仅在客户端断开时结束
+			sctx, cancel := context.WithCancel(context.WithoutCancel(mctx))
+			defer cancel()
+			stop := context.AfterFunc(mctx, func() {
+				if errors.Is(context.Cause(mctx), context.Canceled) {
+					cancel()
+				}
+			})
+			defer stop()
+
+			if err := sw.open(sctx, cancel); err != nil {
+				return nil, err
+			}
+			return nil, h(req.(*Req), &httpServerStream[Reply]{ctx: sctx, w: sw})
+		})
+
+		_, err := handler(context.WithValue(ctx, streamKey{}, struct{}{}), in)
+		switch {
+		case sw.opened():
+			sw.close(err)
+			return nil
+		case sw.failed():
+			// 建立流时已写回 HTTP 错误响应，不能再次写入
+			return nil
+		}
+		// 流尚未建立（如鉴权/参数校验失败），按普通 HTTP 错误响应
+		return err
+	})
+}
+
+type streamKey struct{}
+
+// isStream 是否为 RegisterStream 注册的流式调用
+func isStream(ctx context.Context) bool {
+	_, ok := ctx.Value(streamKey{}).(struct{})
+	return ok
+}
+
+// streamWriter 负责单条流的传输层细节（SSE / WebSocket）
+type streamWriter interface {
+	open(ctx context.Context, cancel context.CancelFunc) error
+	opened() bool
+	// failed open 失败且已写回 HTTP 响应（如 WebSocket 升级失败）
+	failed() bool
+	write(v any) error
+	close(err error)
+}
+
+func newStreamWriter(ctx khttp.Context, codec encoding.Codec) streamWriter {
+	req := ctx.Request()
+	if websocket.IsWebSocketUpgrade(req) {
+		return &wsWriter{res: ctx.Response(), req: req, codec: codec}
+	}
+	return &sseWriter{res: ctx.Response(), codec: codec}
+}
+
+// sseWriter Server-Sent Events：
+// - 消息：data: <json>\n\n
+// - 心跳：: ping\n\n（注释帧，EventSource 会忽略）
+// - 流建立后出错：event: error\ndata: <kratos errors.Status json>\n\n
+type sseWriter struct {
+	res   http.ResponseWriter
+	rc    *http.ResponseController
+	codec encoding.Codec
+
+	mu     sync.Mutex
+	isOpen bool
+	done   chan struct{}
+}
+
+func (w *sseWriter) open(ctx context.Context, cancel context.CancelFunc) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// 写入响应头前确认支持 Flush，否则仍可按普通 HTTP 错误响应
+	if !canFlush(w.res) {
+		return errors.New("sse: response writer does not support flush")
+	}
+	h := w.res.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no")
+	w.res.WriteHeader(http.StatusOK)
+
+	// 响应头已写出，之后的错误经 close 以 event: error 帧返回
+	w.rc = http.NewResponseController(w.res)
+	w.isOpen = true
+	w.done = make(chan struct{})
+	if err := w.rc.Flush(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(streamHeartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.done:
+				return
+			case <-ticker.C:
+				if err := w.frame(": ping\n\n"); err != nil {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (w *sseWriter) opened() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.isOpen
+}
+
+func (w *sseWriter) failed() bool { return false }
+
+// canFlush 与 http.ResponseController 一致，沿 Unwrap 链查找 http.Flusher
+func canFlush(w http.ResponseWriter) bool {
+	for {
+		switch t := w.(type) {
+		case http.Flusher:
+			return true
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = t.Unwrap()
+		default:
+			return false
+		}
+	}
+}
+
+func (w *sseWriter) write(v any) error {
+	data, err := w.codec.Marshal(v)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return w.frame("data: " + string(data) + "\n\n")
+}
+
+func (w *sseWriter) close(err error) {
+	close(w.done)
+	if err == nil {
+		return
+	}
+	data, mErr := w.codec.Marshal(kerrors.FromError(err))
+	if mErr != nil {
+		return
+	}
+	_ = w.frame("event: error\ndata: " + string(data) + "\n\n")
+}
+
+func (w *sseWriter) frame(s string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.res.Write([]byte(s)); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(w.rc.Flush())
+}
+
+// wsWriter WebSocket：
+// - 消息：text 帧，内容为 json
+// - 心跳：ping 控制帧；读循环收到 close / 读失败即视为客户端断开
+// - 结束：正常结束发送 1000，出错发送 1011 且 reason 为错误信息
+type wsWriter struct {
+	res   http.ResponseWriter
+	req   *http.Request
+	codec encoding.Codec
+
+	mu            sync.Mutex
+	conn          *websocket.Conn
+	upgradeFailed bool
+	done          chan struct{}
+}
+
+func (w *wsWriter) open(ctx context.Context, cancel context.CancelFunc) error {
+	conn, err := wsUpgrader.Upgrade(w.res, w.req, nil)
+	if err != nil {
+		// Upgrade 失败时已写回 HTTP 错误响应
+		w.mu.Lock()
+		w.upgradeFailed = true
+		w.mu.Unlock()
+		return errors.WithStack(err)
+	}
+
+	w.mu.Lock()
+	w.conn = conn
+	w.done = make(chan struct{})
+	w.mu.Unlock()
+
+	// 服务端流不接收客户端消息，读循环仅用于处理控制帧与探测断连
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(streamHeartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.done:
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteTimeout)); err != nil {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (w *wsWriter) opened() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.conn != nil
+}
+
+func (w *wsWriter) failed() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.upgradeFailed
+}
+
+func (w *wsWriter) write(v any) error {
+	data, err := w.codec.Marshal(v)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_ = w.conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	return errors.WithStack(w.conn.WriteMessage(websocket.TextMessage, data))
+}
+
+func (w *wsWriter) close(err error) {
+	close(w.done)
+
+	msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	if err != nil {
+		reason := kerrors.FromError(err).GetMessage()
+		if len(reason) > wsCloseReasonLimit {
+			reason = strings.ToValidUTF8(reason[:wsCloseReasonLimit], "")
+		}
+		msg = websocket.FormatCloseMessage(websocket.CloseInternalServerErr, reason)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_ = w.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(streamWriteTimeout))
+	_ = w.conn.Close()
+}
+
+// httpServerStream 适配 grpc.ServerStreamingServer，使 gRPC 流式方法可直接复用于 HTTP
+type httpServerStream[Reply any] struct {
+	ctx context.Context
+	w   streamWriter
+}
+
+func (s *httpServerStream[Reply]) Send(m *Reply) error {
+	return s.w.write(m)
+}
+
+func (s *httpServerStream[Reply]) Context() context.Context {
+	return s.ctx
+}
+
+// SetHeader / SendHeader / SetTrailer 在流建立后无法再修改 HTTP 头，忽略即可
+func (s *httpServerStream[Reply]) SetHeader(metadata.MD) error  { return nil }
+func (s *httpServerStream[Reply]) SendHeader(metadata.MD) error { return nil }
+func (s *httpServerStream[Reply]) SetTrailer(metadata.MD)       {}
+
+func (s *httpServerStream[Reply]) SendMsg(m any) error {
+	reply, ok := m.(*Reply)
+	if !ok {
+		return errors.Errorf("unexpected stream message type %T", m)
+	}
+	return s.Send(reply)
+}
+
+func (s *httpServerStream[Reply]) RecvMsg(any) error {
+	return errors.New("recv is not supported on http server stream")
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/encoding"
+	"github.com/go-kratos/kratos/v2/encoding/json"
+	"github.com/go-kratos/kratos/v2/middleware"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+)
+
+const testStreamPath = "/apis/test/v1/watch"
+
+// plainWriter 不支持 Flush / Hijack 的 ResponseWriter，记录每次 WriteHeader 的状态码
+type plainWriter struct {
+	header http.Header
+	codes  []int
+	body   bytes.Buffer
+}
+
+func newPlainWriter() *plainWriter {
+	return &plainWriter{header: http.Header{}}
+}
+
+func (w *plainWriter) Header() http.Header { return w.header }
+
+func (w *plainWriter) Write(b []byte) (int, error) {
+	if len(w.codes) == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.body.Write(b)
+}
+
+func (w *plainWriter) WriteHeader(code int) { w.codes = append(w.codes, code) }
+
+// newTestStreamServer 注册一条发送 hello 后结束的流，返回 server 与请求指标中间件的调用次数
+func newTestStreamServer(t *testing.T) (*khttp.Server, *atomic.Int32) {
+	t.Helper()
+	var measured atomic.Int32
+	metrics := func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			measured.Add(1)
+			return handler(ctx, req)
+		}
+	}
+	rf := newRequestFilter(&conf.AccessLog{})
+	srv := khttp.NewServer(khttp.Middleware(rf.Metrics(metrics)))
+	RegisterStream(srv, encoding.GetCodec(json.Name), testStreamPath, "/test.v1.TestService/Watch",
+		func(_ *emptypb.Empty, ss ggrpc.ServerStreamingServer[wrapperspb.StringValue]) error {
+			return ss.Send(wrapperspb.String("hello"))
+		})
+	return srv, &measured
+}
+
+func TestStreamSSE(t *testing.T) {
+	srv, measured := newTestStreamServer(t)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodGet, testStreamPath, nil))
+
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("got %d %s, want 200 text/event-stream", w.Code, w.Header().Get("Content-Type"))
+	}
+	if body := w.Body.String(); body != "data: \"hello\"\n\n" {
+		t.Fatalf("got body %q", body)
+	}
+	// 流不计入请求指标
+	if n := measured.Load(); n != 0 {
+		t.Fatalf("stream measured %d times, want 0", n)
+	}
+}
+
+func TestStreamSSEFlushUnsupported(t *testing.T) {
+	srv, _ := newTestStreamServer(t)
+	w := newPlainWriter()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodGet, testStreamPath, nil))
+
+	// 未写出 SSE 响应头，按普通 HTTP 错误只响应一次
+	if len(w.codes) != 1 || w.codes[0] != http.StatusInternalServerError {
+		t.Fatalf("got status codes %v, want [500]", w.codes)
+	}
+	if ct := w.header.Get("Content-Type"); strings.HasPrefix(ct, "text/event-stream") {
+		t.Fatalf("got content type %s for a stream that was not opened", ct)
+	}
+}
+
+func TestStreamWebSocketUpgradeFailed(t *testing.T) {
+	srv, _ := newTestStreamServer(t)
+	w := newPlainWriter()
+	req := httptest.NewRequest(http.MethodGet, testStreamPath, nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	srv.ServeHTTP(w, req)
+
+	// 缺少 Sec-WebSocket-Version / Key 时 Upgrade 已写回 400，不能再写入错误响应
+	if len(w.codes) != 1 || w.codes[0] != http.StatusBadRequest {
+		t.Fatalf("got status codes %v, want [400]", w.codes)
+	}
+}
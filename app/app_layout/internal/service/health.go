@@ -2,20 +2,25 @@ package service
 
 import (
 	"context"
-	"net"
+	"maps"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/jeffinity/singularity/buildinfo"
-	"github.com/jeffinity/singularity/kratosx"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
-	"github.com/jeffinity/app-layout/app/app_layout/internal/biz"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/app_init"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/health"
 	healthv1 "github.com/jeffinity/app-layout/pkg/health"
 )
 
@@ -23,26 +28,31 @@ var (
 	startTime = time.Now()
 )
 
-const (
-	checkTimeout        = 800 * time.Millisecond // 单项检查超时
-	kafkaDialTimeout    = 700 * time.Millisecond // Kafka 握手超时
-	kafkaControllerWait = 500 * time.Millisecond // 调 Controller() 的超时
-)
+// defaultWatchHeartbeat Watch 默认心跳间隔
+const defaultWatchHeartbeat = 30 * time.Second
 
 type HealthService struct {
 	healthv1.UnimplementedHealthServiceServer
 
-	logger *log.Helper
+	info    app_init.AppInfo
+	prober  *health.Prober
+	startup *health.Startup
+	logger  *log.Helper
 }
 
 func NewHealthService(
 	logger log.Logger,
 	conf *conf.Bootstrap,
-	_ *biz.HelloUseCase, // 演示注入
+	info app_init.AppInfo,
+	prober *health.Prober,
+	startup *health.Startup,
 ) *HealthService {
 
 	return &HealthService{
-		logger: log.NewHelper(log.With(logger, "module", "app_layout/HealthService")),
+		info:    info,
+		prober:  prober,
+		startup: startup,
+		logger:  log.NewHelper(log.With(logger, "module", "app_layout/HealthService")),
 	}
 }
 
@@ -50,69 +60,127 @@ func (s *HealthService) Liveness(_ context.Context, _ *healthv1.HealthCheckReque
 	return &emptypb.Empty{}, nil
 }
 
-func (s *HealthService) Readiness(ctx context.Context, _ *healthv1.HealthCheckRequest) (*emptypb.Empty, error) {
-	var errs []string
+func (s *HealthService) Startup(_ context.Context, _ *healthv1.HealthCheckRequest) (*emptypb.Empty, error) {
+	if err := s.startup.Check(); err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return &emptypb.Empty{}, nil
+}
 
-	// TODO add service availability check
-	//if err := s.checkPostgres(ctx); err != nil {
-	//	errs = append(errs, "postgres: "+err.Error())
-	//}
-	//if err := s.checkRedis(ctx); err != nil {
-	//	errs = append(errs, "redis: "+err.Error())
-	//}
-	//if err := s.checkKafka(ctx); err != nil {
-	//	errs = append(errs, "kafka: "+err.Error())
-	//}
+func (s *HealthService) Readiness(_ context.Context, _ *healthv1.HealthCheckRequest) (*emptypb.Empty, error) {
+	states := s.prober.Snapshot()
+	if err := health.Ready(s.startup, states); err != nil {
+		s.logger.Errorf("readiness not ready: %s", err)
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
 
-	if len(errs) > 0 {
-		s.logger.Errorf("readiness not ready: %s", strings.Join(errs, "; "))
-		return nil, status.Error(codes.Unavailable, strings.Join(errs, "; "))
+	var degraded []string
+	for _, st := range states {
+		if st.Status == healthv1.Status_STATUS_DOWN {
+			degraded = append(degraded, st.Name+": "+st.Reason)
+		}
+	}
+	if len(degraded) > 0 {
+		s.logger.Warnf("readiness degraded: %s", strings.Join(degraded, "; "))
 	}
 	return &emptypb.Empty{}, nil
 }
 
-func (s *HealthService) Status(ctx context.Context, _ *healthv1.StatusRequest) (*healthv1.StatusReply, error) {
-	now := time.Now()
+func (s *HealthService) Status(ctx context.Context, req *healthv1.StatusRequest) (*healthv1.StatusReply, error) {
+	states, err := filterStates(s.prober.Snapshot(), req)
+	if err != nil {
+		return nil, err
+	}
+	return s.statusReply(ctx, states, req.GetVerbose()), nil
+}
 
-	type item struct {
-		name   string
-		checkF func(context.Context) error
+func (s *HealthService) Watch(req *healthv1.WatchRequest, stream grpc.ServerStreamingServer[healthv1.StatusReply]) error {
+	ctx := stream.Context()
+	sreq := &healthv1.StatusRequest{
+		Names:    req.GetNames(),
+		Exclude:  req.GetExclude(),
+		SkipSlow: req.GetSkipSlow(),
+		Verbose:  req.GetVerbose(),
+	}
+	heartbeat := defaultWatchHeartbeat
+	if req.GetHeartbeatSeconds() > 0 {
+		heartbeat = time.Duration(req.GetHeartbeatSeconds()) * time.Second
 	}
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
 
-	items := []item{
-		//{name: "postgres", checkF: s.checkPostgres},
-		//{name: "redis", checkF: s.checkRedis},
-		//{name: "kafka", checkF: s.checkKafka},
+	var last string
+	push := func(force bool) error {
+		states, err := filterStates(s.prober.Snapshot(), sreq)
+		if err != nil {
+			return err
+		}
+		fp := watchFingerprint(states)
+		if !force && fp == last {
+			return nil
+		}
+		last = fp
+		ticker.Reset(heartbeat)
+		return stream.Send(s.statusReply(ctx, states, sreq.GetVerbose()))
 	}
 
-	var checks []*healthv1.Check
-	overall := healthv1.Status_STATUS_UP
+	changed := s.prober.Changed()
+	if err := push(true); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+			changed = s.prober.Changed()
+			if err := push(false); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if err := push(true); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// watchFingerprint 整体状态与各检查项状态的摘要，仅在其变化时推送
+func watchFingerprint(states []health.CheckState) string {
+	var b strings.Builder
+	b.WriteString(health.Overall(states).String())
+	for _, st := range states {
+		b.WriteString("|" + st.Name + "=" + st.Status.String())
+	}
+	return b.String()
+}
 
-	for _, it := range items {
-		start := time.Now()
-		ctxi, cancel := context.WithTimeout(ctx, checkTimeout)
-		err := it.checkF(ctxi)
-		cancel()
+func (s *HealthService) statusReply(ctx context.Context, states []health.CheckState, verbose bool) *healthv1.StatusReply {
+	now := time.Now()
 
+	var checks []*healthv1.Check
+	for _, st := range states {
 		chk := &healthv1.Check{
-			Name:      it.name,
-			Status:    healthv1.Status_STATUS_UP,
-			Reason:    "",
-			LatencyMs: int64(time.Since(start) / time.Millisecond),
-			Since:     timestamppb.New(startTime),
-			Metadata:  map[string]string{},
+			Name:      st.Name,
+			Status:    st.Status,
+			Reason:    st.Reason,
+			LatencyMs: st.Latency.Milliseconds(),
+			Metadata:  map[string]string{"critical": strconv.FormatBool(st.Critical)},
 		}
-		if err != nil {
-			chk.Status = healthv1.Status_STATUS_DOWN
-			chk.Reason = err.Error()
-			overall = healthv1.Status_STATUS_DOWN
+		if !st.Since.IsZero() {
+			chk.Since = timestamppb.New(st.Since)
+		}
+		if verbose {
+			maps.Copy(chk.Metadata, s.prober.Metadata(ctx, st.Name))
+			chk.Metadata["slow"] = strconv.FormatBool(st.Slow)
 		}
 		checks = append(checks, chk)
 	}
 
 	reply := &healthv1.StatusReply{
-		Overall: overall,
-		Service: kratosx.ServiceNameProbeCenter,
+		Overall: health.Overall(states),
+		Service: s.info.Name,
+		PcId:    string(s.info.ID),
 		VersionInfo: &healthv1.Version{
 			Version:   buildinfo.Version,
 			BuildTime: buildinfo.BuildTime,
@@ -126,84 +194,100 @@ func (s *HealthService) Status(ctx context.Context, _ *healthv1.StatusRequest) (
 		Now:           timestamppb.New(now),
 		Checks:        checks,
 	}
+	if verbose {
+		reply.Runtime = runtimeInfo()
+	}
+	for _, pi := range s.startup.Phases() {
+		reply.Phases = append(reply.Phases, toPhase(pi))
+	}
+	return reply
+}
 
-	return reply, nil
+func toPhase(pi health.PhaseInfo) *healthv1.Phase {
+	p := &healthv1.Phase{
+		Name:       pi.Name,
+		State:      pi.State,
+		DurationMs: pi.Duration.Milliseconds(),
+	}
+	if !pi.StartedAt.IsZero() {
+		p.StartedAt = timestamppb.New(pi.StartedAt)
+	}
+	if !pi.FinishedAt.IsZero() {
+		p.FinishedAt = timestamppb.New(pi.FinishedAt)
+	}
+	if pi.Err != nil {
+		p.Error = pi.Err.Error()
+	}
+	return p
 }
 
-//func (s *HealthService) checkPostgres(ctx context.Context) error {
-//	if s.data == nil || s.data.Pg == nil {
-//		return errors.New("gorm db is nil")
-//	}
-//	sqlDB, err := s.data.Pg.DB()
-//	if err != nil {
-//		return fmt.Errorf("get sqldb: %w", err)
-//	}
-//	if err := sqlDB.PingContext(ctx); err != nil {
-//		return fmt.Errorf("ping: %w", err)
-//	}
-//	return nil
-//}
-//
-//func (s *HealthService) checkRedis(ctx context.Context) error {
-//	if s.data == nil || s.data.RDB == nil {
-//		return errors.New("redis client is nil")
-//	}
-//	if err := s.data.RDB.Ping(ctx).Err(); err != nil {
-//		return fmt.Errorf("ping: %w", err)
-//	}
-//	return nil
-//}
-//
-//func (s *HealthService) checkKafka(ctx context.Context) error {
-//	if len(s.kafkaBrokers) == 0 {
-//		return errors.New("no kafka brokers provided")
-//	}
-//
-//	var lastErr error
-//	for _, addr := range s.kafkaBrokers {
-//		if err := tcpPing(ctx, addr, kafkaDialTimeout); err != nil {
-//			lastErr = fmt.Errorf("tcp dial %s: %w", addr, err)
-//			continue
-//		}
-//
-//		var conn *kafka.Conn
-//		var err error
-//
-//		dctx, cancel := context.WithTimeout(ctx, kafkaDialTimeout)
-//		conn, err = kafka.DialContext(dctx, "tcp", addr)
-//		cancel()
-//
-//		if err != nil {
-//			lastErr = fmt.Errorf("kafka dial %s: %w", addr, err)
-//			continue
-//		}
-//
-//		_ = conn.SetDeadline(time.Now().Add(kafkaControllerWait))
-//		_, err = conn.Controller()
-//		_ = conn.Close()
-//
-//		if err != nil {
-//			lastErr = fmt.Errorf("controller query %s: %w", addr, err)
-//			continue
-//		}
-//		return nil
-//	}
-//
-//	if lastErr == nil {
-//		lastErr = errors.New("unknown kafka error")
-//	}
-//	return lastErr
-//}
-
-func tcpPing(ctx context.Context, addr string, timeout time.Duration) error {
-	var d net.Dialer
-	if timeout > 0 {
-		d.Timeout = timeout
-	}
-	c, err := d.DialContext(ctx, "tcp", addr)
-	if err != nil {
-		return err
+// filterStates 按 StatusRequest 的 names / exclude / skip_slow 过滤检查项，names 含未注册的检查项时返回 InvalidArgument
+func filterStates(states []health.CheckState, req *healthv1.StatusRequest) ([]health.CheckState, error) {
+	if len(req.GetNames()) == 0 && len(req.GetExclude()) == 0 && !req.GetSkipSlow() {
+		return states, nil
+	}
+
+	known := make(map[string]struct{}, len(states))
+	for _, st := range states {
+		known[st.Name] = struct{}{}
+	}
+	var unknown []string
+	for _, name := range req.GetNames() {
+		if _, ok := known[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown health checks: %s", strings.Join(unknown, ", "))
 	}
-	_ = c.Close()
-	return nil
+
+	filtered := make([]health.CheckState, 0, len(states))
+	for _, st := range states {
+		if len(req.GetNames()) > 0 && !slices.Contains(req.GetNames(), st.Name) {
+			continue
+		}
+		if slices.Contains(req.GetExclude(), st.Name) {
+			continue
+		}
+		if req.GetSkipSlow() && st.Slow {
+			continue
+		}
+		filtered = append(filtered, st)
+	}
+	return filtered, nil
+}
+
+func runtimeInfo() map[string]string {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+
+	info := map[string]string{
+		"pid":              strconv.Itoa(os.Getpid()),
+		"goroutines":       strconv.Itoa(runtime.NumGoroutine()),
+		"num_cpu":          strconv.Itoa(runtime.NumCPU()),
+		"gomaxprocs":       strconv.Itoa(runtime.GOMAXPROCS(0)),
+		"heap_alloc_bytes": strconv.FormatUint(ms.HeapAlloc, 10),
+		"heap_sys_bytes":   strconv.FormatUint(ms.HeapSys, 10),
+		"sys_bytes":        strconv.FormatUint(ms.Sys, 10),
+		"num_gc":           strconv.FormatUint(uint64(ms.NumGC), 10),
+	}
+	if ms.LastGC > 0 {
+		info["last_gc"] = time.Unix(0, int64(ms.LastGC)).Format(time.RFC3339)
+	}
+	return info
+}
+
+func (s *HealthService) History(_ context.Context, req *healthv1.HistoryRequest) (*healthv1.HistoryReply, error) {
+	ts := s.prober.History(req.GetName(), int(req.GetLimit()))
+	reply := &healthv1.HistoryReply{Transitions: make([]*healthv1.Transition, 0, len(ts))}
+	for _, t := range ts {
+		reply.Transitions = append(reply.Transitions, &healthv1.Transition{
+			Name:   t.Name,
+			From:   t.From,
+			To:     t.To,
+			At:     timestamppb.New(t.At),
+			Reason: t.Reason,
+		})
+	}
+	return reply, nil
 }
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/jeffinity/app-layout/app/app_layout/internal/biz"
+	hellov1 "github.com/jeffinity/app-layout/pkg/hello"
+)
+
+type HelloService struct {
+	hellov1.UnimplementedHelloServiceServer
+
+	uc     *biz.HelloUseCase
+	logger *log.Helper
+}
+
+func NewHelloService(logger log.Logger, uc *biz.HelloUseCase) *HelloService {
+	return &HelloService{
+		uc:     uc,
+		logger: log.NewHelper(log.With(logger, "module", "app_layout/HelloService")),
+	}
+}
+
+func (s *HelloService) CreateHello(ctx context.Context, req *hellov1.CreateHelloRequest) (*hellov1.Hello, error) {
+	h, err := s.uc.Create(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return toHello(h), nil
+}
+
+func (s *HelloService) GetHello(ctx context.Context, req *hellov1.GetHelloRequest) (*hellov1.Hello, error) {
+	h, err := s.uc.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return toHello(h), nil
+}
+
+func (s *HelloService) UpdateHello(ctx context.Context, req *hellov1.UpdateHelloRequest) (*hellov1.Hello, error) {
+	h, err := s.uc.Update(ctx, req.GetId(), req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return toHello(h), nil
+}
+
+func (s *HelloService) DeleteHello(ctx context.Context, req *hellov1.DeleteHelloRequest) (*emptypb.Empty, error) {
+	if err := s.uc.Delete(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *HelloService) ListHellos(ctx context.Context, req *hellov1.ListHellosRequest) (*hellov1.ListHellosReply, error) {
+	hs, total, err := s.uc.List(ctx, int(req.GetPage()), int(req.GetPageSize()), req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	reply := &hellov1.ListHellosReply{Hellos: make([]*hellov1.Hello, 0, len(hs)), Total: total}
+	for _, h := range hs {
+		reply.Hellos = append(reply.Hellos, toHello(h))
+	}
+	return reply, nil
+}
+
+func toHello(h *biz.Hello) *hellov1.Hello {
+	return &hellov1.Hello{
+		Id:        h.ID,
+		Name:      h.Name,
+		CreatedAt: timestamppb.New(h.CreatedAt),
+		UpdatedAt: timestamppb.New(h.UpdatedAt),
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
 	"sync"
 
 	"github.com/go-kratos/kratos/v2/config/file"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/jeffinity/singularity/kratosx"
+	"github.com/jeffinity/singularity/migratex"
 	"github.com/oklog/ulid/v2"
 
 	"github.com/jeffinity/app-layout/app/app_layout/internal/app_init"
@@ -21,6 +24,7 @@ type Resource struct {
 	logger *log.Helper
 
 	data      *data.Data
+	migrator  *migratex.Migrator
 	helloRepo biz.HelloRepo
 	helloUC   *biz.HelloUseCase
 }
@@ -29,23 +33,49 @@ func newTestResource(
 	ctx context.Context,
 	logger log.Logger,
 	data *data.Data,
+	migrator *migratex.Migrator,
 	helloRepo biz.HelloRepo,
 	helloUC *biz.HelloUseCase,
 ) *Resource {
 	return &Resource{
 		ctx:       ctx,
 		data:      data,
+		migrator:  migrator,
 		helloUC:   helloUC,
 		helloRepo: helloRepo,
 		logger:    log.NewHelper(logger),
 	}
 }
 
+// Context 测试使用的根 ctx
+func (r *Resource) Context() context.Context { return r.ctx }
+
+// Data 数据层依赖，可用于 InTx / DB 等事务相关测试
+func (r *Resource) Data() *data.Data { return r.data }
+
+// HelloRepo data 层 HelloRepo 实现
+func (r *Resource) HelloRepo() biz.HelloRepo { return r.helloRepo }
+
+// HelloUseCase biz 层 HelloUseCase
+func (r *Resource) HelloUseCase() *biz.HelloUseCase { return r.helloUC }
+
+func newAppInfo(pcID kratosx.ServiceID) app_init.AppInfo {
+	return app_init.AppInfo{Name: kratosx.ServiceNameProbeCenter, ID: pcID}
+}
+
+// getConfigPathEnv 优先使用 APP_LAYOUT_CONFIG_PATH，未设置时使用 configs/config_test.yaml（内存 SQLite），
+// 按源码位置定位，go test 在任意包目录下执行均可找到
 func getConfigPathEnv() string {
-	return os.Getenv("APP_LAYOUT_CONFIG_PATH")
+	if p := os.Getenv("APP_LAYOUT_CONFIG_PATH"); p != "" {
+		return p
+	}
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "configs", "config_test.yaml")
 }
 
-// InitTest 针对 data or biz 层依赖 conf 加载后实现的单测，可以执行 InitTest 来获取相关的资源进行测试
+// InitTest 针对 data or biz 层依赖 conf 加载后实现的单测，可以执行 InitTest 来获取相关的资源进行测试；
+// 初始化时自动迁移所有数据表，使用 SQLite（如 configs/config_test.yaml）时无需外部数据库；
+// 每次调用创建独立的资源，使用内存库时各测试的数据互不影响
 func InitTest() *Resource {
 
 	configPath := getConfigPathEnv()
@@ -72,6 +102,9 @@ func InitTest() *Resource {
 	if err != nil {
 		log.Fatal(err)
 	}
+	if err := resource.migrator.MigrateAll(rootCtx); err != nil {
+		log.Fatal(err)
+	}
 
 	defer cleanup()
 	return resource
@@ -17,13 +17,18 @@ import (
 	"github.com/jeffinity/app-layout/app/app_layout/internal/biz"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/data"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/health"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/metricx"
 )
 
 func InitTestResource(pcID kratosx.ServiceID, logger log.Logger, root context.Context, wg *sync.WaitGroup, c *conf.Bootstrap) (*Resource, func(), error) {
 	panic(wire.Build(
 		newTestResource,
+		newAppInfo,
 		//app_init.NewNacosConf,
+		metricx.ProviderSet,
 		data.ProviderSet,
 		biz.ProviderSet,
+		health.ProviderSet,
 	))
 }
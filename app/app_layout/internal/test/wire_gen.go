@@ -12,6 +12,8 @@ import (
 	"github.com/jeffinity/app-layout/app/app_layout/internal/biz"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/conf"
 	"github.com/jeffinity/app-layout/app/app_layout/internal/data"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/health"
+	"github.com/jeffinity/app-layout/app/app_layout/internal/metricx"
 	"github.com/jeffinity/singularity/kratosx"
 	"sync"
 )
@@ -19,23 +21,52 @@ import (
 // Injectors from wire.go:
 
 func InitTestResource(pcID kratosx.ServiceID, logger log.Logger, root context.Context, wg *sync.WaitGroup, c *conf.Bootstrap) (*Resource, func(), error) {
-	db, err := data.NewPostgres(c, logger)
+	replicas, cleanup, err := data.NewReplicas(c, logger)
 	if err != nil {
 		return nil, nil, err
 	}
-	clusterClient, cleanup, err := data.NewRedis(root, c, logger)
+	db, err := data.NewDB(c, replicas, logger)
 	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	universalClient, cleanup2, err := data.NewRedis(root, c, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	registry := health.NewRegistry(c)
+	appInfo := newAppInfo(pcID)
+	metricxRegistry, cleanup3, err := metricx.NewRegistry(appInfo, c, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	dataData, cleanup4, err := data.NewData(c, db, replicas, universalClient, registry, metricxRegistry, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
 		return nil, nil, err
 	}
-	dataData, cleanup2, err := data.NewData(c, db, clusterClient, logger)
+	migrator := data.NewAllMigrator(dataData)
+	cacheMetrics, err := data.NewCacheMetrics(metricxRegistry)
 	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	helloRepo := data.NewHelloRepo(dataData, logger)
-	helloUseCase := biz.NewHelloUseCase(helloRepo, logger)
-	resource := newTestResource(root, logger, dataData, helloRepo, helloUseCase)
+	helloRepo := data.NewHelloRepo(dataData, cacheMetrics, logger)
+	transaction := data.NewTransaction(dataData)
+	outbox := data.NewOutbox(dataData)
+	helloUseCase := biz.NewHelloUseCase(helloRepo, transaction, outbox, logger)
+	resource := newTestResource(root, logger, dataData, migrator, helloRepo, helloUseCase)
 	return resource, func() {
+		cleanup4()
+		cleanup3()
 		cleanup2()
 		cleanup()
 	}, nil
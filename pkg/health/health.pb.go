@@ -80,6 +80,66 @@ func (Status) EnumDescriptor() ([]byte, []int) {
 	return file_health_proto_rawDescGZIP(), []int{0}
 }
 
+// 初始化阶段状态
+type PhaseState int32
+
+const (
+	PhaseState_PHASE_STATE_UNUSED PhaseState = 0
+	// 已注册，尚未开始
+	PhaseState_PHASE_STATE_PENDING PhaseState = 1
+	// 执行中
+	PhaseState_PHASE_STATE_RUNNING PhaseState = 2
+	// 已完成
+	PhaseState_PHASE_STATE_DONE PhaseState = 3
+	// 失败
+	PhaseState_PHASE_STATE_FAILED PhaseState = 4
+)
+
+// Enum value maps for PhaseState.
+var (
+	PhaseState_name = map[int32]string{
+		0: "PHASE_STATE_UNUSED",
+		1: "PHASE_STATE_PENDING",
+		2: "PHASE_STATE_RUNNING",
+		3: "PHASE_STATE_DONE",
+		4: "PHASE_STATE_FAILED",
+	}
+	PhaseState_value = map[string]int32{
+		"PHASE_STATE_UNUSED":  0,
+		"PHASE_STATE_PENDING": 1,
+		"PHASE_STATE_RUNNING": 2,
+		"PHASE_STATE_DONE":    3,
+		"PHASE_STATE_FAILED":  4,
+	}
+)
+
+func (x PhaseState) Enum() *PhaseState {
+	p := new(PhaseState)
+	*p = x
+	return p
+}
+
+func (x PhaseState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PhaseState) Descriptor() protoreflect.EnumDescriptor {
+	return file_health_proto_enumTypes[1].Descriptor()
+}
+
+func (PhaseState) Type() protoreflect.EnumType {
+	return &file_health_proto_enumTypes[1]
+}
+
+func (x PhaseState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PhaseState.Descriptor instead.
+func (PhaseState) EnumDescriptor() ([]byte, []int) {
+	return file_health_proto_rawDescGZIP(), []int{1}
+}
+
 type HealthCheckRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -117,7 +177,15 @@ func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
 }
 
 type StatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 仅返回这些检查项，为空返回全部
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	// 排除这些检查项
+	Exclude []string `protobuf:"bytes,2,rep,name=exclude,proto3" json:"exclude,omitempty"`
+	// 跳过声明为慢检查的项（如跨机房依赖、Kafka 等）
+	SkipSlow bool `protobuf:"varint,3,opt,name=skip_slow,json=skipSlow,proto3" json:"skip_slow,omitempty"`
+	// 返回详细信息：检查项 metadata（连接池、地址等）与进程运行时信息
+	Verbose       bool `protobuf:"varint,4,opt,name=verbose,proto3" json:"verbose,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -152,6 +220,34 @@ func (*StatusRequest) Descriptor() ([]byte, []int) {
 	return file_health_proto_rawDescGZIP(), []int{1}
 }
 
+func (x *StatusRequest) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *StatusRequest) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+func (x *StatusRequest) GetSkipSlow() bool {
+	if x != nil {
+		return x.SkipSlow
+	}
+	return false
+}
+
+func (x *StatusRequest) GetVerbose() bool {
+	if x != nil {
+		return x.Verbose
+	}
+	return false
+}
+
 // 详细检查项
 type Check struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -257,7 +353,13 @@ type StatusReply struct {
 	// 生成该响应的时间
 	Now *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=now,proto3" json:"now,omitempty"`
 	// 依赖项列表
-	Checks        []*Check `protobuf:"bytes,8,rep,name=checks,proto3" json:"checks,omitempty"`
+	Checks []*Check `protobuf:"bytes,8,rep,name=checks,proto3" json:"checks,omitempty"`
+	// 实例 ID
+	PcId string `protobuf:"bytes,9,opt,name=pc_id,json=pcId,proto3" json:"pc_id,omitempty"`
+	// 进程运行时信息（goroutines、内存、GC 等），仅 verbose 时返回
+	Runtime map[string]string `protobuf:"bytes,10,rep,name=runtime,proto3" json:"runtime,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 初始化阶段列表（按注册顺序）
+	Phases        []*Phase `protobuf:"bytes,11,rep,name=phases,proto3" json:"phases,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -334,6 +436,379 @@ func (x *StatusReply) GetChecks() []*Check {
 	return nil
 }
 
+func (x *StatusReply) GetPcId() string {
+	if x != nil {
+		return x.PcId
+	}
+	return ""
+}
+
+func (x *StatusReply) GetRuntime() map[string]string {
+	if x != nil {
+		return x.Runtime
+	}
+	return nil
+}
+
+func (x *StatusReply) GetPhases() []*Phase {
+	if x != nil {
+		return x.Phases
+	}
+	return nil
+}
+
+// 初始化阶段，如数据库迁移、缓存预热、配置加载
+type Phase struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 阶段名
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 阶段状态
+	State PhaseState `protobuf:"varint,2,opt,name=state,proto3,enum=gainetics.probe_executor.health.v1.PhaseState" json:"state,omitempty"`
+	// 开始时间
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// 结束时间
+	FinishedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	// 耗时，执行中为已执行时长
+	DurationMs int64 `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// 失败原因
+	Error         string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Phase) Reset() {
+	*x = Phase{}
+	mi := &file_health_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Phase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Phase) ProtoMessage() {}
+
+func (x *Phase) ProtoReflect() protoreflect.Message {
+	mi := &file_health_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Phase.ProtoReflect.Descriptor instead.
+func (*Phase) Descriptor() ([]byte, []int) {
+	return file_health_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Phase) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Phase) GetState() PhaseState {
+	if x != nil {
+		return x.State
+	}
+	return PhaseState_PHASE_STATE_UNUSED
+}
+
+func (x *Phase) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Phase) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *Phase) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *Phase) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type WatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 同 StatusRequest.names
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	// 同 StatusRequest.exclude
+	Exclude []string `protobuf:"bytes,2,rep,name=exclude,proto3" json:"exclude,omitempty"`
+	// 同 StatusRequest.skip_slow
+	SkipSlow bool `protobuf:"varint,3,opt,name=skip_slow,json=skipSlow,proto3" json:"skip_slow,omitempty"`
+	// 同 StatusRequest.verbose
+	Verbose bool `protobuf:"varint,4,opt,name=verbose,proto3" json:"verbose,omitempty"`
+	// 心跳间隔（秒），<= 0 时为 30
+	HeartbeatSeconds int32 `protobuf:"varint,5,opt,name=heartbeat_seconds,json=heartbeatSeconds,proto3" json:"heartbeat_seconds,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_health_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_health_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_health_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WatchRequest) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *WatchRequest) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+func (x *WatchRequest) GetSkipSlow() bool {
+	if x != nil {
+		return x.SkipSlow
+	}
+	return false
+}
+
+func (x *WatchRequest) GetVerbose() bool {
+	if x != nil {
+		return x.Verbose
+	}
+	return false
+}
+
+func (x *WatchRequest) GetHeartbeatSeconds() int32 {
+	if x != nil {
+		return x.HeartbeatSeconds
+	}
+	return 0
+}
+
+type HistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 仅返回该检查项的变更，为空返回全部
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 最多返回条数，0 表示返回全部保留的记录
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryRequest) Reset() {
+	*x = HistoryRequest{}
+	mi := &file_health_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryRequest) ProtoMessage() {}
+
+func (x *HistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_health_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryRequest.ProtoReflect.Descriptor instead.
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
+	return file_health_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HistoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// 检查项状态变更
+type Transition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 依赖名
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 变更前状态
+	From Status `protobuf:"varint,2,opt,name=from,proto3,enum=gainetics.probe_executor.health.v1.Status" json:"from,omitempty"`
+	// 变更后状态
+	To Status `protobuf:"varint,3,opt,name=to,proto3,enum=gainetics.probe_executor.health.v1.Status" json:"to,omitempty"`
+	// 变更时间
+	At *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=at,proto3" json:"at,omitempty"`
+	// 触发变更的错因或说明
+	Reason        string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transition) Reset() {
+	*x = Transition{}
+	mi := &file_health_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transition) ProtoMessage() {}
+
+func (x *Transition) ProtoReflect() protoreflect.Message {
+	mi := &file_health_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transition.ProtoReflect.Descriptor instead.
+func (*Transition) Descriptor() ([]byte, []int) {
+	return file_health_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Transition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Transition) GetFrom() Status {
+	if x != nil {
+		return x.From
+	}
+	return Status_STATUS_UNUSED
+}
+
+func (x *Transition) GetTo() Status {
+	if x != nil {
+		return x.To
+	}
+	return Status_STATUS_UNUSED
+}
+
+func (x *Transition) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *Transition) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type HistoryReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transitions   []*Transition          `protobuf:"bytes,1,rep,name=transitions,proto3" json:"transitions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryReply) Reset() {
+	*x = HistoryReply{}
+	mi := &file_health_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryReply) ProtoMessage() {}
+
+func (x *HistoryReply) ProtoReflect() protoreflect.Message {
+	mi := &file_health_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryReply.ProtoReflect.Descriptor instead.
+func (*HistoryReply) Descriptor() ([]byte, []int) {
+	return file_health_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *HistoryReply) GetTransitions() []*Transition {
+	if x != nil {
+		return x.Transitions
+	}
+	return nil
+}
+
 type Version struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
@@ -349,7 +824,7 @@ type Version struct {
 
 func (x *Version) Reset() {
 	*x = Version{}
-	mi := &file_health_proto_msgTypes[4]
+	mi := &file_health_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -361,7 +836,7 @@ func (x *Version) String() string {
 func (*Version) ProtoMessage() {}
 
 func (x *Version) ProtoReflect() protoreflect.Message {
-	mi := &file_health_proto_msgTypes[4]
+	mi := &file_health_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -374,7 +849,7 @@ func (x *Version) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Version.ProtoReflect.Descriptor instead.
 func (*Version) Descriptor() ([]byte, []int) {
-	return file_health_proto_rawDescGZIP(), []int{4}
+	return file_health_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Version) GetVersion() string {
@@ -431,8 +906,12 @@ var File_health_proto protoreflect.FileDescriptor
 const file_health_proto_rawDesc = "" +
 	"\n" +
 	"\fhealth.proto\x12\"gainetics.probe_executor.health.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\"\x14\n" +
-	"\x12HealthCheckRequest\"\x0f\n" +
-	"\rStatusRequest\"\xda\x02\n" +
+	"\x12HealthCheckRequest\"v\n" +
+	"\rStatusRequest\x12\x14\n" +
+	"\x05names\x18\x01 \x03(\tR\x05names\x12\x18\n" +
+	"\aexclude\x18\x02 \x03(\tR\aexclude\x12\x1b\n" +
+	"\tskip_slow\x18\x03 \x01(\bR\bskipSlow\x12\x18\n" +
+	"\averbose\x18\x04 \x01(\bR\averbose\"\xda\x02\n" +
 	"\x05Check\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12B\n" +
 	"\x06status\x18\x02 \x01(\x0e2*.gainetics.probe_executor.health.v1.StatusR\x06status\x12\x16\n" +
@@ -443,14 +922,49 @@ const file_health_proto_rawDesc = "" +
 	"\bmetadata\x18\x06 \x03(\v27.gainetics.probe_executor.health.v1.Check.MetadataEntryR\bmetadata\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd5\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc1\x04\n" +
 	"\vStatusReply\x12D\n" +
 	"\aoverall\x18\x01 \x01(\x0e2*.gainetics.probe_executor.health.v1.StatusR\aoverall\x12\x18\n" +
 	"\aservice\x18\x02 \x01(\tR\aservice\x12N\n" +
 	"\fversion_info\x18\x03 \x01(\v2+.gainetics.probe_executor.health.v1.VersionR\vversionInfo\x12%\n" +
 	"\x0euptime_seconds\x18\x06 \x01(\x03R\ruptimeSeconds\x12,\n" +
 	"\x03now\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x03now\x12A\n" +
-	"\x06checks\x18\b \x03(\v2).gainetics.probe_executor.health.v1.CheckR\x06checks\"\xd1\x01\n" +
+	"\x06checks\x18\b \x03(\v2).gainetics.probe_executor.health.v1.CheckR\x06checks\x12\x13\n" +
+	"\x05pc_id\x18\t \x01(\tR\x04pcId\x12V\n" +
+	"\aruntime\x18\n" +
+	" \x03(\v2<.gainetics.probe_executor.health.v1.StatusReply.RuntimeEntryR\aruntime\x12A\n" +
+	"\x06phases\x18\v \x03(\v2).gainetics.probe_executor.health.v1.PhaseR\x06phases\x1a:\n" +
+	"\fRuntimeEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x90\x02\n" +
+	"\x05Phase\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12D\n" +
+	"\x05state\x18\x02 \x01(\x0e2..gainetics.probe_executor.health.v1.PhaseStateR\x05state\x129\n" +
+	"\n" +
+	"started_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\x12\x1f\n" +
+	"\vduration_ms\x18\x05 \x01(\x03R\n" +
+	"durationMs\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"\xa2\x01\n" +
+	"\fWatchRequest\x12\x14\n" +
+	"\x05names\x18\x01 \x03(\tR\x05names\x12\x18\n" +
+	"\aexclude\x18\x02 \x03(\tR\aexclude\x12\x1b\n" +
+	"\tskip_slow\x18\x03 \x01(\bR\bskipSlow\x12\x18\n" +
+	"\averbose\x18\x04 \x01(\bR\averbose\x12+\n" +
+	"\x11heartbeat_seconds\x18\x05 \x01(\x05R\x10heartbeatSeconds\":\n" +
+	"\x0eHistoryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xe0\x01\n" +
+	"\n" +
+	"Transition\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12>\n" +
+	"\x04from\x18\x02 \x01(\x0e2*.gainetics.probe_executor.health.v1.StatusR\x04from\x12:\n" +
+	"\x02to\x18\x03 \x01(\x0e2*.gainetics.probe_executor.health.v1.StatusR\x02to\x12*\n" +
+	"\x02at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"`\n" +
+	"\fHistoryReply\x12P\n" +
+	"\vtransitions\x18\x01 \x03(\v2..gainetics.probe_executor.health.v1.TransitionR\vtransitions\"\xd1\x01\n" +
 	"\aVersion\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1d\n" +
 	"\n" +
@@ -466,11 +980,21 @@ const file_health_proto_rawDesc = "" +
 	"\rSTATUS_UNUSED\x10\x00\x12\r\n" +
 	"\tSTATUS_UP\x10\x01\x12\x0f\n" +
 	"\vSTATUS_DOWN\x10\x02\x12\x13\n" +
-	"\x0fSTATUS_DEGRADED\x10\x032\xa0\x03\n" +
+	"\x0fSTATUS_DEGRADED\x10\x03*\x84\x01\n" +
+	"\n" +
+	"PhaseState\x12\x16\n" +
+	"\x12PHASE_STATE_UNUSED\x10\x00\x12\x17\n" +
+	"\x13PHASE_STATE_PENDING\x10\x01\x12\x17\n" +
+	"\x13PHASE_STATE_RUNNING\x10\x02\x12\x14\n" +
+	"\x10PHASE_STATE_DONE\x10\x03\x12\x16\n" +
+	"\x12PHASE_STATE_FAILED\x10\x042\xce\x06\n" +
 	"\rHealthService\x12{\n" +
 	"\bLiveness\x126.gainetics.probe_executor.health.v1.HealthCheckRequest\x1a\x16.google.protobuf.Empty\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/apis/health/v1/healthz\x12{\n" +
-	"\tReadiness\x126.gainetics.probe_executor.health.v1.HealthCheckRequest\x1a\x16.google.protobuf.Empty\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/apis/health/v1/readyz\x12\x94\x01\n" +
-	"\x06Status\x121.gainetics.probe_executor.health.v1.StatusRequest\x1a/.gainetics.probe_executor.health.v1.StatusReply\"&\x82\xd3\xe4\x93\x02 \x12\x1e/apis/health/v1/healthz/statusB5Z3github.com/jeffinity/app-layout/pkg/health;healthv1b\x06proto3"
+	"\tReadiness\x126.gainetics.probe_executor.health.v1.HealthCheckRequest\x1a\x16.google.protobuf.Empty\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/apis/health/v1/readyz\x12{\n" +
+	"\aStartup\x126.gainetics.probe_executor.health.v1.HealthCheckRequest\x1a\x16.google.protobuf.Empty\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/apis/health/v1/startupz\x12\x94\x01\n" +
+	"\x06Status\x121.gainetics.probe_executor.health.v1.StatusRequest\x1a/.gainetics.probe_executor.health.v1.StatusReply\"&\x82\xd3\xe4\x93\x02 \x12\x1e/apis/health/v1/healthz/status\x12\x98\x01\n" +
+	"\aHistory\x122.gainetics.probe_executor.health.v1.HistoryRequest\x1a0.gainetics.probe_executor.health.v1.HistoryReply\"'\x82\xd3\xe4\x93\x02!\x12\x1f/apis/health/v1/healthz/history\x12\x93\x01\n" +
+	"\x05Watch\x120.gainetics.probe_executor.health.v1.WatchRequest\x1a/.gainetics.probe_executor.health.v1.StatusReply\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/apis/health/v1/healthz/watch0\x01B5Z3github.com/jeffinity/app-layout/pkg/health;healthv1b\x06proto3"
 
 var (
 	file_health_proto_rawDescOnce sync.Once
@@ -484,38 +1008,60 @@ func file_health_proto_rawDescGZIP() []byte {
 	return file_health_proto_rawDescData
 }
 
-var file_health_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_health_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_health_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_health_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_health_proto_goTypes = []any{
 	(Status)(0),                   // 0: gainetics.probe_executor.health.v1.Status
-	(*HealthCheckRequest)(nil),    // 1: gainetics.probe_executor.health.v1.HealthCheckRequest
-	(*StatusRequest)(nil),         // 2: gainetics.probe_executor.health.v1.StatusRequest
-	(*Check)(nil),                 // 3: gainetics.probe_executor.health.v1.Check
-	(*StatusReply)(nil),           // 4: gainetics.probe_executor.health.v1.StatusReply
-	(*Version)(nil),               // 5: gainetics.probe_executor.health.v1.Version
-	nil,                           // 6: gainetics.probe_executor.health.v1.Check.MetadataEntry
-	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 8: google.protobuf.Empty
+	(PhaseState)(0),               // 1: gainetics.probe_executor.health.v1.PhaseState
+	(*HealthCheckRequest)(nil),    // 2: gainetics.probe_executor.health.v1.HealthCheckRequest
+	(*StatusRequest)(nil),         // 3: gainetics.probe_executor.health.v1.StatusRequest
+	(*Check)(nil),                 // 4: gainetics.probe_executor.health.v1.Check
+	(*StatusReply)(nil),           // 5: gainetics.probe_executor.health.v1.StatusReply
+	(*Phase)(nil),                 // 6: gainetics.probe_executor.health.v1.Phase
+	(*WatchRequest)(nil),          // 7: gainetics.probe_executor.health.v1.WatchRequest
+	(*HistoryRequest)(nil),        // 8: gainetics.probe_executor.health.v1.HistoryRequest
+	(*Transition)(nil),            // 9: gainetics.probe_executor.health.v1.Transition
+	(*HistoryReply)(nil),          // 10: gainetics.probe_executor.health.v1.HistoryReply
+	(*Version)(nil),               // 11: gainetics.probe_executor.health.v1.Version
+	nil,                           // 12: gainetics.probe_executor.health.v1.Check.MetadataEntry
+	nil,                           // 13: gainetics.probe_executor.health.v1.StatusReply.RuntimeEntry
+	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 15: google.protobuf.Empty
 }
 var file_health_proto_depIdxs = []int32{
 	0,  // 0: gainetics.probe_executor.health.v1.Check.status:type_name -> gainetics.probe_executor.health.v1.Status
-	7,  // 1: gainetics.probe_executor.health.v1.Check.since:type_name -> google.protobuf.Timestamp
-	6,  // 2: gainetics.probe_executor.health.v1.Check.metadata:type_name -> gainetics.probe_executor.health.v1.Check.MetadataEntry
+	14, // 1: gainetics.probe_executor.health.v1.Check.since:type_name -> google.protobuf.Timestamp
+	12, // 2: gainetics.probe_executor.health.v1.Check.metadata:type_name -> gainetics.probe_executor.health.v1.Check.MetadataEntry
 	0,  // 3: gainetics.probe_executor.health.v1.StatusReply.overall:type_name -> gainetics.probe_executor.health.v1.Status
-	5,  // 4: gainetics.probe_executor.health.v1.StatusReply.version_info:type_name -> gainetics.probe_executor.health.v1.Version
-	7,  // 5: gainetics.probe_executor.health.v1.StatusReply.now:type_name -> google.protobuf.Timestamp
-	3,  // 6: gainetics.probe_executor.health.v1.StatusReply.checks:type_name -> gainetics.probe_executor.health.v1.Check
-	1,  // 7: gainetics.probe_executor.health.v1.HealthService.Liveness:input_type -> gainetics.probe_executor.health.v1.HealthCheckRequest
-	1,  // 8: gainetics.probe_executor.health.v1.HealthService.Readiness:input_type -> gainetics.probe_executor.health.v1.HealthCheckRequest
-	2,  // 9: gainetics.probe_executor.health.v1.HealthService.Status:input_type -> gainetics.probe_executor.health.v1.StatusRequest
-	8,  // 10: gainetics.probe_executor.health.v1.HealthService.Liveness:output_type -> google.protobuf.Empty
-	8,  // 11: gainetics.probe_executor.health.v1.HealthService.Readiness:output_type -> google.protobuf.Empty
-	4,  // 12: gainetics.probe_executor.health.v1.HealthService.Status:output_type -> gainetics.probe_executor.health.v1.StatusReply
-	10, // [10:13] is the sub-list for method output_type
-	7,  // [7:10] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	11, // 4: gainetics.probe_executor.health.v1.StatusReply.version_info:type_name -> gainetics.probe_executor.health.v1.Version
+	14, // 5: gainetics.probe_executor.health.v1.StatusReply.now:type_name -> google.protobuf.Timestamp
+	4,  // 6: gainetics.probe_executor.health.v1.StatusReply.checks:type_name -> gainetics.probe_executor.health.v1.Check
+	13, // 7: gainetics.probe_executor.health.v1.StatusReply.runtime:type_name -> gainetics.probe_executor.health.v1.StatusReply.RuntimeEntry
+	6,  // 8: gainetics.probe_executor.health.v1.StatusReply.phases:type_name -> gainetics.probe_executor.health.v1.Phase
+	1,  // 9: gainetics.probe_executor.health.v1.Phase.state:type_name -> gainetics.probe_executor.health.v1.PhaseState
+	14, // 10: gainetics.probe_executor.health.v1.Phase.started_at:type_name -> google.protobuf.Timestamp
+	14, // 11: gainetics.probe_executor.health.v1.Phase.finished_at:type_name -> google.protobuf.Timestamp
+	0,  // 12: gainetics.probe_executor.health.v1.Transition.from:type_name -> gainetics.probe_executor.health.v1.Status
+	0,  // 13: gainetics.probe_executor.health.v1.Transition.to:type_name -> gainetics.probe_executor.health.v1.Status
+	14, // 14: gainetics.probe_executor.health.v1.Transition.at:type_name -> google.protobuf.Timestamp
+	9,  // 15: gainetics.probe_executor.health.v1.HistoryReply.transitions:type_name -> gainetics.probe_executor.health.v1.Transition
+	2,  // 16: gainetics.probe_executor.health.v1.HealthService.Liveness:input_type -> gainetics.probe_executor.health.v1.HealthCheckRequest
+	2,  // 17: gainetics.probe_executor.health.v1.HealthService.Readiness:input_type -> gainetics.probe_executor.health.v1.HealthCheckRequest
+	2,  // 18: gainetics.probe_executor.health.v1.HealthService.Startup:input_type -> gainetics.probe_executor.health.v1.HealthCheckRequest
+	3,  // 19: gainetics.probe_executor.health.v1.HealthService.Status:input_type -> gainetics.probe_executor.health.v1.StatusRequest
+	8,  // 20: gainetics.probe_executor.health.v1.HealthService.History:input_type -> gainetics.probe_executor.health.v1.HistoryRequest
+	7,  // 21: gainetics.probe_executor.health.v1.HealthService.Watch:input_type -> gainetics.probe_executor.health.v1.WatchRequest
+	15, // 22: gainetics.probe_executor.health.v1.HealthService.Liveness:output_type -> google.protobuf.Empty
+	15, // 23: gainetics.probe_executor.health.v1.HealthService.Readiness:output_type -> google.protobuf.Empty
+	15, // 24: gainetics.probe_executor.health.v1.HealthService.Startup:output_type -> google.protobuf.Empty
+	5,  // 25: gainetics.probe_executor.health.v1.HealthService.Status:output_type -> gainetics.probe_executor.health.v1.StatusReply
+	10, // 26: gainetics.probe_executor.health.v1.HealthService.History:output_type -> gainetics.probe_executor.health.v1.HistoryReply
+	5,  // 27: gainetics.probe_executor.health.v1.HealthService.Watch:output_type -> gainetics.probe_executor.health.v1.StatusReply
+	22, // [22:28] is the sub-list for method output_type
+	16, // [16:22] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_health_proto_init() }
@@ -528,8 +1074,8 @@ func file_health_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_health_proto_rawDesc), len(file_health_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   6,
+			NumEnums:      2,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
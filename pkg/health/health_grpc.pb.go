@@ -22,7 +22,10 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	HealthService_Liveness_FullMethodName  = "/gainetics.probe_executor.health.v1.HealthService/Liveness"
 	HealthService_Readiness_FullMethodName = "/gainetics.probe_executor.health.v1.HealthService/Readiness"
+	HealthService_Startup_FullMethodName   = "/gainetics.probe_executor.health.v1.HealthService/Startup"
 	HealthService_Status_FullMethodName    = "/gainetics.probe_executor.health.v1.HealthService/Status"
+	HealthService_History_FullMethodName   = "/gainetics.probe_executor.health.v1.HealthService/History"
+	HealthService_Watch_FullMethodName     = "/gainetics.probe_executor.health.v1.HealthService/Watch"
 )
 
 // HealthServiceClient is the client API for HealthService service.
@@ -39,8 +42,18 @@ type HealthServiceClient interface {
 	// - 就绪时返回 gRPC OK（HTTP 200）
 	// - 未就绪时返回 gRPC Unavailable（HTTP 503）
 	Readiness(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Startup（启动完成：所有初始化阶段已完成）：
+	// - 启动完成返回 gRPC OK（HTTP 200）
+	// - 仍在初始化或初始化失败返回 gRPC Unavailable（HTTP 503）
+	Startup(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// 详细状态（便于排障/观测）
 	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusReply, error)
+	// 检查项状态变更历史（最近的在前）
+	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryReply, error)
+	// 订阅详细状态：建立后立即推送一次当前状态，此后整体状态或任一检查项状态变化时推送，
+	// 无变化时按 heartbeat_seconds 重复推送当前状态作为心跳。
+	// HTTP 以 SSE / WebSocket 暴露（server.RegisterStream 注册，http 注解仅用于生成 OpenAPI 文档）
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StatusReply], error)
 }
 
 type healthServiceClient struct {
@@ -71,6 +84,16 @@ func (c *healthServiceClient) Readiness(ctx context.Context, in *HealthCheckRequ
 	return out, nil
 }
 
+func (c *healthServiceClient) Startup(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, HealthService_Startup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *healthServiceClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusReply, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(StatusReply)
@@ -81,6 +104,35 @@ func (c *healthServiceClient) Status(ctx context.Context, in *StatusRequest, opt
 	return out, nil
 }
 
+func (c *healthServiceClient) History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HistoryReply)
+	err := c.cc.Invoke(ctx, HealthService_History_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *healthServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StatusReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HealthService_ServiceDesc.Streams[0], HealthService_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, StatusReply]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HealthService_WatchClient = grpc.ServerStreamingClient[StatusReply]
+
 // HealthServiceServer is the server API for HealthService service.
 // All implementations must embed UnimplementedHealthServiceServer
 // for forward compatibility.
@@ -95,8 +147,18 @@ type HealthServiceServer interface {
 	// - 就绪时返回 gRPC OK（HTTP 200）
 	// - 未就绪时返回 gRPC Unavailable（HTTP 503）
 	Readiness(context.Context, *HealthCheckRequest) (*emptypb.Empty, error)
+	// Startup（启动完成：所有初始化阶段已完成）：
+	// - 启动完成返回 gRPC OK（HTTP 200）
+	// - 仍在初始化或初始化失败返回 gRPC Unavailable（HTTP 503）
+	Startup(context.Context, *HealthCheckRequest) (*emptypb.Empty, error)
 	// 详细状态（便于排障/观测）
 	Status(context.Context, *StatusRequest) (*StatusReply, error)
+	// 检查项状态变更历史（最近的在前）
+	History(context.Context, *HistoryRequest) (*HistoryReply, error)
+	// 订阅详细状态：建立后立即推送一次当前状态，此后整体状态或任一检查项状态变化时推送，
+	// 无变化时按 heartbeat_seconds 重复推送当前状态作为心跳。
+	// HTTP 以 SSE / WebSocket 暴露（server.RegisterStream 注册，http 注解仅用于生成 OpenAPI 文档）
+	Watch(*WatchRequest, grpc.ServerStreamingServer[StatusReply]) error
 	mustEmbedUnimplementedHealthServiceServer()
 }
 
@@ -113,9 +175,18 @@ func (UnimplementedHealthServiceServer) Liveness(context.Context, *HealthCheckRe
 func (UnimplementedHealthServiceServer) Readiness(context.Context, *HealthCheckRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method Readiness not implemented")
 }
+func (UnimplementedHealthServiceServer) Startup(context.Context, *HealthCheckRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method Startup not implemented")
+}
 func (UnimplementedHealthServiceServer) Status(context.Context, *StatusRequest) (*StatusReply, error) {
 	return nil, status.Error(codes.Unimplemented, "method Status not implemented")
 }
+func (UnimplementedHealthServiceServer) History(context.Context, *HistoryRequest) (*HistoryReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method History not implemented")
+}
+func (UnimplementedHealthServiceServer) Watch(*WatchRequest, grpc.ServerStreamingServer[StatusReply]) error {
+	return status.Error(codes.Unimplemented, "method Watch not implemented")
+}
 func (UnimplementedHealthServiceServer) mustEmbedUnimplementedHealthServiceServer() {}
 func (UnimplementedHealthServiceServer) testEmbeddedByValue()                       {}
 
@@ -173,6 +244,24 @@ func _HealthService_Readiness_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HealthService_Startup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HealthServiceServer).Startup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HealthService_Startup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HealthServiceServer).Startup(ctx, req.(*HealthCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _HealthService_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StatusRequest)
 	if err := dec(in); err != nil {
@@ -191,6 +280,35 @@ func _HealthService_Status_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HealthService_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HealthServiceServer).History(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HealthService_History_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HealthServiceServer).History(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HealthService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HealthServiceServer).Watch(m, &grpc.GenericServerStream[WatchRequest, StatusReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HealthService_WatchServer = grpc.ServerStreamingServer[StatusReply]
+
 // HealthService_ServiceDesc is the grpc.ServiceDesc for HealthService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -206,11 +324,25 @@ var HealthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Readiness",
 			Handler:    _HealthService_Readiness_Handler,
 		},
+		{
+			MethodName: "Startup",
+			Handler:    _HealthService_Startup_Handler,
+		},
 		{
 			MethodName: "Status",
 			Handler:    _HealthService_Status_Handler,
 		},
+		{
+			MethodName: "History",
+			Handler:    _HealthService_History_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _HealthService_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "health.proto",
 }
@@ -20,11 +20,15 @@ var _ = binding.EncodeURL
 
 const _ = http.SupportPackageIsVersion1
 
+const OperationHealthServiceHistory = "/gainetics.probe_executor.health.v1.HealthService/History"
 const OperationHealthServiceLiveness = "/gainetics.probe_executor.health.v1.HealthService/Liveness"
 const OperationHealthServiceReadiness = "/gainetics.probe_executor.health.v1.HealthService/Readiness"
+const OperationHealthServiceStartup = "/gainetics.probe_executor.health.v1.HealthService/Startup"
 const OperationHealthServiceStatus = "/gainetics.probe_executor.health.v1.HealthService/Status"
 
 type HealthServiceHTTPServer interface {
+	// History 检查项状态变更历史（最近的在前）
+	History(context.Context, *HistoryRequest) (*HistoryReply, error)
 	// Liveness Liveness（存活）：
 	// - 健康时返回 gRPC OK（HTTP 200）
 	// - 不健康时返回 gRPC Unavailable（HTTP 503）
@@ -33,6 +37,10 @@ type HealthServiceHTTPServer interface {
 	// - 就绪时返回 gRPC OK（HTTP 200）
 	// - 未就绪时返回 gRPC Unavailable（HTTP 503）
 	Readiness(context.Context, *HealthCheckRequest) (*emptypb.Empty, error)
+	// Startup Startup（启动完成：所有初始化阶段已完成）：
+	// - 启动完成返回 gRPC OK（HTTP 200）
+	// - 仍在初始化或初始化失败返回 gRPC Unavailable（HTTP 503）
+	Startup(context.Context, *HealthCheckRequest) (*emptypb.Empty, error)
 	// Status 详细状态（便于排障/观测）
 	Status(context.Context, *StatusRequest) (*StatusReply, error)
 }
@@ -41,7 +49,9 @@ func RegisterHealthServiceHTTPServer(s *http.Server, srv HealthServiceHTTPServer
 	r := s.Route("/")
 	r.GET("/apis/health/v1/healthz", _HealthService_Liveness0_HTTP_Handler(srv))
 	r.GET("/apis/health/v1/readyz", _HealthService_Readiness0_HTTP_Handler(srv))
+	r.GET("/apis/health/v1/startupz", _HealthService_Startup0_HTTP_Handler(srv))
 	r.GET("/apis/health/v1/healthz/status", _HealthService_Status0_HTTP_Handler(srv))
+	r.GET("/apis/health/v1/healthz/history", _HealthService_History0_HTTP_Handler(srv))
 }
 
 func _HealthService_Liveness0_HTTP_Handler(srv HealthServiceHTTPServer) func(ctx http.Context) error {
@@ -82,6 +92,25 @@ func _HealthService_Readiness0_HTTP_Handler(srv HealthServiceHTTPServer) func(ct
 	}
 }
 
+func _HealthService_Startup0_HTTP_Handler(srv HealthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in HealthCheckRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationHealthServiceStartup)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.Startup(ctx, req.(*HealthCheckRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*emptypb.Empty)
+		return ctx.Result(200, reply)
+	}
+}
+
 func _HealthService_Status0_HTTP_Handler(srv HealthServiceHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
 		var in StatusRequest
@@ -101,7 +130,28 @@ func _HealthService_Status0_HTTP_Handler(srv HealthServiceHTTPServer) func(ctx h
 	}
 }
 
+func _HealthService_History0_HTTP_Handler(srv HealthServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in HistoryRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationHealthServiceHistory)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.History(ctx, req.(*HistoryRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*HistoryReply)
+		return ctx.Result(200, reply)
+	}
+}
+
 type HealthServiceHTTPClient interface {
+	// History 检查项状态变更历史（最近的在前）
+	History(ctx context.Context, req *HistoryRequest, opts ...http.CallOption) (rsp *HistoryReply, err error)
 	// Liveness Liveness（存活）：
 	// - 健康时返回 gRPC OK（HTTP 200）
 	// - 不健康时返回 gRPC Unavailable（HTTP 503）
@@ -110,6 +160,10 @@ type HealthServiceHTTPClient interface {
 	// - 就绪时返回 gRPC OK（HTTP 200）
 	// - 未就绪时返回 gRPC Unavailable（HTTP 503）
 	Readiness(ctx context.Context, req *HealthCheckRequest, opts ...http.CallOption) (rsp *emptypb.Empty, err error)
+	// Startup Startup（启动完成：所有初始化阶段已完成）：
+	// - 启动完成返回 gRPC OK（HTTP 200）
+	// - 仍在初始化或初始化失败返回 gRPC Unavailable（HTTP 503）
+	Startup(ctx context.Context, req *HealthCheckRequest, opts ...http.CallOption) (rsp *emptypb.Empty, err error)
 	// Status 详细状态（便于排障/观测）
 	Status(ctx context.Context, req *StatusRequest, opts ...http.CallOption) (rsp *StatusReply, err error)
 }
@@ -122,6 +176,20 @@ func NewHealthServiceHTTPClient(client *http.Client) HealthServiceHTTPClient {
 	return &HealthServiceHTTPClientImpl{client}
 }
 
+// History 检查项状态变更历史（最近的在前）
+func (c *HealthServiceHTTPClientImpl) History(ctx context.Context, in *HistoryRequest, opts ...http.CallOption) (*HistoryReply, error) {
+	var out HistoryReply
+	pattern := "/apis/health/v1/healthz/history"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationHealthServiceHistory))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // Liveness Liveness（存活）：
 // - 健康时返回 gRPC OK（HTTP 200）
 // - 不健康时返回 gRPC Unavailable（HTTP 503）
@@ -154,6 +222,22 @@ func (c *HealthServiceHTTPClientImpl) Readiness(ctx context.Context, in *HealthC
 	return &out, nil
 }
 
+// Startup Startup（启动完成：所有初始化阶段已完成）：
+// - 启动完成返回 gRPC OK（HTTP 200）
+// - 仍在初始化或初始化失败返回 gRPC Unavailable（HTTP 503）
+func (c *HealthServiceHTTPClientImpl) Startup(ctx context.Context, in *HealthCheckRequest, opts ...http.CallOption) (*emptypb.Empty, error) {
+	var out emptypb.Empty
+	pattern := "/apis/health/v1/startupz"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationHealthServiceStartup))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // Status 详细状态（便于排障/观测）
 func (c *HealthServiceHTTPClientImpl) Status(ctx context.Context, in *StatusRequest, opts ...http.CallOption) (*StatusReply, error) {
 	var out StatusReply
@@ -0,0 +1,499 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.31.0
+// source: hello.proto
+
+package hellov1
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Hello struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hello) Reset() {
+	*x = Hello{}
+	mi := &file_hello_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hello) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hello) ProtoMessage() {}
+
+func (x *Hello) ProtoReflect() protoreflect.Message {
+	mi := &file_hello_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hello.ProtoReflect.Descriptor instead.
+func (*Hello) Descriptor() ([]byte, []int) {
+	return file_hello_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Hello) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Hello) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Hello) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Hello) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateHelloRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateHelloRequest) Reset() {
+	*x = CreateHelloRequest{}
+	mi := &file_hello_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateHelloRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateHelloRequest) ProtoMessage() {}
+
+func (x *CreateHelloRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hello_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateHelloRequest.ProtoReflect.Descriptor instead.
+func (*CreateHelloRequest) Descriptor() ([]byte, []int) {
+	return file_hello_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateHelloRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetHelloRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHelloRequest) Reset() {
+	*x = GetHelloRequest{}
+	mi := &file_hello_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHelloRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHelloRequest) ProtoMessage() {}
+
+func (x *GetHelloRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hello_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHelloRequest.ProtoReflect.Descriptor instead.
+func (*GetHelloRequest) Descriptor() ([]byte, []int) {
+	return file_hello_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetHelloRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type UpdateHelloRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateHelloRequest) Reset() {
+	*x = UpdateHelloRequest{}
+	mi := &file_hello_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateHelloRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateHelloRequest) ProtoMessage() {}
+
+func (x *UpdateHelloRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hello_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateHelloRequest.ProtoReflect.Descriptor instead.
+func (*UpdateHelloRequest) Descriptor() ([]byte, []int) {
+	return file_hello_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateHelloRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateHelloRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteHelloRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteHelloRequest) Reset() {
+	*x = DeleteHelloRequest{}
+	mi := &file_hello_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteHelloRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteHelloRequest) ProtoMessage() {}
+
+func (x *DeleteHelloRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hello_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteHelloRequest.ProtoReflect.Descriptor instead.
+func (*DeleteHelloRequest) Descriptor() ([]byte, []int) {
+	return file_hello_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteHelloRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListHellosRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 页码，从 1 开始，<= 0 时为 1
+	Page int32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	// 每页条数，<= 0 时为 20
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// 按名称前缀过滤，为空不过滤
+	Name          string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHellosRequest) Reset() {
+	*x = ListHellosRequest{}
+	mi := &file_hello_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHellosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHellosRequest) ProtoMessage() {}
+
+func (x *ListHellosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hello_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHellosRequest.ProtoReflect.Descriptor instead.
+func (*ListHellosRequest) Descriptor() ([]byte, []int) {
+	return file_hello_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListHellosRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListHellosRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListHellosRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListHellosReply struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Hellos []*Hello               `protobuf:"bytes,1,rep,name=hellos,proto3" json:"hellos,omitempty"`
+	// 满足过滤条件的总数
+	Total         int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHellosReply) Reset() {
+	*x = ListHellosReply{}
+	mi := &file_hello_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHellosReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHellosReply) ProtoMessage() {}
+
+func (x *ListHellosReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hello_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHellosReply.ProtoReflect.Descriptor instead.
+func (*ListHellosReply) Descriptor() ([]byte, []int) {
+	return file_hello_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListHellosReply) GetHellos() []*Hello {
+	if x != nil {
+		return x.Hellos
+	}
+	return nil
+}
+
+func (x *ListHellosReply) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+var File_hello_proto protoreflect.FileDescriptor
+
+const file_hello_proto_rawDesc = "" +
+	"\n" +
+	"\vhello.proto\x12!gainetics.probe_executor.hello.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\"\xa1\x01\n" +
+	"\x05Hello\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"3\n" +
+	"\x12CreateHelloRequest\x12\x1d\n" +
+	"\x04name\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\x04name\"*\n" +
+	"\x0fGetHelloRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x02id\"L\n" +
+	"\x12UpdateHelloRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x02id\x12\x1d\n" +
+	"\x04name\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@R\x04name\"-\n" +
+	"\x12DeleteHelloRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x02id\"j\n" +
+	"\x11ListHellosRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12$\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02\x18dR\bpageSize\x12\x1b\n" +
+	"\x04name\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x18@R\x04name\"i\n" +
+	"\x0fListHellosReply\x12@\n" +
+	"\x06hellos\x18\x01 \x03(\v2(.gainetics.probe_executor.hello.v1.HelloR\x06hellos\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total2\xe3\x05\n" +
+	"\fHelloService\x12\x90\x01\n" +
+	"\vCreateHello\x125.gainetics.probe_executor.hello.v1.CreateHelloRequest\x1a(.gainetics.probe_executor.hello.v1.Hello\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/apis/hello/v1/hellos\x12\x8c\x01\n" +
+	"\bGetHello\x122.gainetics.probe_executor.hello.v1.GetHelloRequest\x1a(.gainetics.probe_executor.hello.v1.Hello\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/apis/hello/v1/hellos/{id}\x12\x95\x01\n" +
+	"\vUpdateHello\x125.gainetics.probe_executor.hello.v1.UpdateHelloRequest\x1a(.gainetics.probe_executor.hello.v1.Hello\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\x1a\x1a/apis/hello/v1/hellos/{id}\x12\x80\x01\n" +
+	"\vDeleteHello\x125.gainetics.probe_executor.hello.v1.DeleteHelloRequest\x1a\x16.google.protobuf.Empty\"\"\x82\xd3\xe4\x93\x02\x1c*\x1a/apis/hello/v1/hellos/{id}\x12\x95\x01\n" +
+	"\n" +
+	"ListHellos\x124.gainetics.probe_executor.hello.v1.ListHellosRequest\x1a2.gainetics.probe_executor.hello.v1.ListHellosReply\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/apis/hello/v1/hellosB3Z1github.com/jeffinity/app-layout/pkg/hello;hellov1b\x06proto3"
+
+var (
+	file_hello_proto_rawDescOnce sync.Once
+	file_hello_proto_rawDescData []byte
+)
+
+func file_hello_proto_rawDescGZIP() []byte {
+	file_hello_proto_rawDescOnce.Do(func() {
+		file_hello_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hello_proto_rawDesc), len(file_hello_proto_rawDesc)))
+	})
+	return file_hello_proto_rawDescData
+}
+
+var file_hello_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_hello_proto_goTypes = []any{
+	(*Hello)(nil),                 // 0: gainetics.probe_executor.hello.v1.Hello
+	(*CreateHelloRequest)(nil),    // 1: gainetics.probe_executor.hello.v1.CreateHelloRequest
+	(*GetHelloRequest)(nil),       // 2: gainetics.probe_executor.hello.v1.GetHelloRequest
+	(*UpdateHelloRequest)(nil),    // 3: gainetics.probe_executor.hello.v1.UpdateHelloRequest
+	(*DeleteHelloRequest)(nil),    // 4: gainetics.probe_executor.hello.v1.DeleteHelloRequest
+	(*ListHellosRequest)(nil),     // 5: gainetics.probe_executor.hello.v1.ListHellosRequest
+	(*ListHellosReply)(nil),       // 6: gainetics.probe_executor.hello.v1.ListHellosReply
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 8: google.protobuf.Empty
+}
+var file_hello_proto_depIdxs = []int32{
+	7, // 0: gainetics.probe_executor.hello.v1.Hello.created_at:type_name -> google.protobuf.Timestamp
+	7, // 1: gainetics.probe_executor.hello.v1.Hello.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 2: gainetics.probe_executor.hello.v1.ListHellosReply.hellos:type_name -> gainetics.probe_executor.hello.v1.Hello
+	1, // 3: gainetics.probe_executor.hello.v1.HelloService.CreateHello:input_type -> gainetics.probe_executor.hello.v1.CreateHelloRequest
+	2, // 4: gainetics.probe_executor.hello.v1.HelloService.GetHello:input_type -> gainetics.probe_executor.hello.v1.GetHelloRequest
+	3, // 5: gainetics.probe_executor.hello.v1.HelloService.UpdateHello:input_type -> gainetics.probe_executor.hello.v1.UpdateHelloRequest
+	4, // 6: gainetics.probe_executor.hello.v1.HelloService.DeleteHello:input_type -> gainetics.probe_executor.hello.v1.DeleteHelloRequest
+	5, // 7: gainetics.probe_executor.hello.v1.HelloService.ListHellos:input_type -> gainetics.probe_executor.hello.v1.ListHellosRequest
+	0, // 8: gainetics.probe_executor.hello.v1.HelloService.CreateHello:output_type -> gainetics.probe_executor.hello.v1.Hello
+	0, // 9: gainetics.probe_executor.hello.v1.HelloService.GetHello:output_type -> gainetics.probe_executor.hello.v1.Hello
+	0, // 10: gainetics.probe_executor.hello.v1.HelloService.UpdateHello:output_type -> gainetics.probe_executor.hello.v1.Hello
+	8, // 11: gainetics.probe_executor.hello.v1.HelloService.DeleteHello:output_type -> google.protobuf.Empty
+	6, // 12: gainetics.probe_executor.hello.v1.HelloService.ListHellos:output_type -> gainetics.probe_executor.hello.v1.ListHellosReply
+	8, // [8:13] is the sub-list for method output_type
+	3, // [3:8] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_hello_proto_init() }
+func file_hello_proto_init() {
+	if File_hello_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hello_proto_rawDesc), len(file_hello_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hello_proto_goTypes,
+		DependencyIndexes: file_hello_proto_depIdxs,
+		MessageInfos:      file_hello_proto_msgTypes,
+	}.Build()
+	File_hello_proto = out.File
+	file_hello_proto_goTypes = nil
+	file_hello_proto_depIdxs = nil
+}
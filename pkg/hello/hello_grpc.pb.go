@@ -0,0 +1,288 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.31.0
+// source: hello.proto
+
+package hellov1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	HelloService_CreateHello_FullMethodName = "/gainetics.probe_executor.hello.v1.HelloService/CreateHello"
+	HelloService_GetHello_FullMethodName    = "/gainetics.probe_executor.hello.v1.HelloService/GetHello"
+	HelloService_UpdateHello_FullMethodName = "/gainetics.probe_executor.hello.v1.HelloService/UpdateHello"
+	HelloService_DeleteHello_FullMethodName = "/gainetics.probe_executor.hello.v1.HelloService/DeleteHello"
+	HelloService_ListHellos_FullMethodName  = "/gainetics.probe_executor.hello.v1.HelloService/ListHellos"
+)
+
+// HelloServiceClient is the client API for HelloService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Hello 示例服务：新应用以此为模板实现自己的增删改查
+type HelloServiceClient interface {
+	// 创建
+	CreateHello(ctx context.Context, in *CreateHelloRequest, opts ...grpc.CallOption) (*Hello, error)
+	// 按 ID 查询，不存在时返回 gRPC NotFound（HTTP 404）
+	GetHello(ctx context.Context, in *GetHelloRequest, opts ...grpc.CallOption) (*Hello, error)
+	// 更新，不存在时返回 gRPC NotFound（HTTP 404）
+	UpdateHello(ctx context.Context, in *UpdateHelloRequest, opts ...grpc.CallOption) (*Hello, error)
+	// 删除，不存在时返回 gRPC NotFound（HTTP 404）
+	DeleteHello(ctx context.Context, in *DeleteHelloRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// 分页列表，按 ID 倒序
+	ListHellos(ctx context.Context, in *ListHellosRequest, opts ...grpc.CallOption) (*ListHellosReply, error)
+}
+
+type helloServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHelloServiceClient(cc grpc.ClientConnInterface) HelloServiceClient {
+	return &helloServiceClient{cc}
+}
+
+func (c *helloServiceClient) CreateHello(ctx context.Context, in *CreateHelloRequest, opts ...grpc.CallOption) (*Hello, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Hello)
+	err := c.cc.Invoke(ctx, HelloService_CreateHello_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helloServiceClient) GetHello(ctx context.Context, in *GetHelloRequest, opts ...grpc.CallOption) (*Hello, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Hello)
+	err := c.cc.Invoke(ctx, HelloService_GetHello_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helloServiceClient) UpdateHello(ctx context.Context, in *UpdateHelloRequest, opts ...grpc.CallOption) (*Hello, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Hello)
+	err := c.cc.Invoke(ctx, HelloService_UpdateHello_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helloServiceClient) DeleteHello(ctx context.Context, in *DeleteHelloRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, HelloService_DeleteHello_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helloServiceClient) ListHellos(ctx context.Context, in *ListHellosRequest, opts ...grpc.CallOption) (*ListHellosReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListHellosReply)
+	err := c.cc.Invoke(ctx, HelloService_ListHellos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HelloServiceServer is the server API for HelloService service.
+// All implementations must embed UnimplementedHelloServiceServer
+// for forward compatibility.
+//
+// Hello 示例服务：新应用以此为模板实现自己的增删改查
+type HelloServiceServer interface {
+	// 创建
+	CreateHello(context.Context, *CreateHelloRequest) (*Hello, error)
+	// 按 ID 查询，不存在时返回 gRPC NotFound（HTTP 404）
+	GetHello(context.Context, *GetHelloRequest) (*Hello, error)
+	// 更新，不存在时返回 gRPC NotFound（HTTP 404）
+	UpdateHello(context.Context, *UpdateHelloRequest) (*Hello, error)
+	// 删除，不存在时返回 gRPC NotFound（HTTP 404）
+	DeleteHello(context.Context, *DeleteHelloRequest) (*emptypb.Empty, error)
+	// 分页列表，按 ID 倒序
+	ListHellos(context.Context, *ListHellosRequest) (*ListHellosReply, error)
+	mustEmbedUnimplementedHelloServiceServer()
+}
+
+// UnimplementedHelloServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedHelloServiceServer struct{}
+
+func (UnimplementedHelloServiceServer) CreateHello(context.Context, *CreateHelloRequest) (*Hello, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateHello not implemented")
+}
+func (UnimplementedHelloServiceServer) GetHello(context.Context, *GetHelloRequest) (*Hello, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHello not implemented")
+}
+func (UnimplementedHelloServiceServer) UpdateHello(context.Context, *UpdateHelloRequest) (*Hello, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateHello not implemented")
+}
+func (UnimplementedHelloServiceServer) DeleteHello(context.Context, *DeleteHelloRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteHello not implemented")
+}
+func (UnimplementedHelloServiceServer) ListHellos(context.Context, *ListHellosRequest) (*ListHellosReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListHellos not implemented")
+}
+func (UnimplementedHelloServiceServer) mustEmbedUnimplementedHelloServiceServer() {}
+func (UnimplementedHelloServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeHelloServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HelloServiceServer will
+// result in compilation errors.
+type UnsafeHelloServiceServer interface {
+	mustEmbedUnimplementedHelloServiceServer()
+}
+
+func RegisterHelloServiceServer(s grpc.ServiceRegistrar, srv HelloServiceServer) {
+	// If the following call panics, it indicates UnimplementedHelloServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&HelloService_ServiceDesc, srv)
+}
+
+func _HelloService_CreateHello_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateHelloRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelloServiceServer).CreateHello(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HelloService_CreateHello_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelloServiceServer).CreateHello(ctx, req.(*CreateHelloRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelloService_GetHello_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHelloRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelloServiceServer).GetHello(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HelloService_GetHello_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelloServiceServer).GetHello(ctx, req.(*GetHelloRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelloService_UpdateHello_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateHelloRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelloServiceServer).UpdateHello(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HelloService_UpdateHello_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelloServiceServer).UpdateHello(ctx, req.(*UpdateHelloRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelloService_DeleteHello_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteHelloRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelloServiceServer).DeleteHello(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HelloService_DeleteHello_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelloServiceServer).DeleteHello(ctx, req.(*DeleteHelloRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelloService_ListHellos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHellosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelloServiceServer).ListHellos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HelloService_ListHellos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelloServiceServer).ListHellos(ctx, req.(*ListHellosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HelloService_ServiceDesc is the grpc.ServiceDesc for HelloService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HelloService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gainetics.probe_executor.hello.v1.HelloService",
+	HandlerType: (*HelloServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateHello",
+			Handler:    _HelloService_CreateHello_Handler,
+		},
+		{
+			MethodName: "GetHello",
+			Handler:    _HelloService_GetHello_Handler,
+		},
+		{
+			MethodName: "UpdateHello",
+			Handler:    _HelloService_UpdateHello_Handler,
+		},
+		{
+			MethodName: "DeleteHello",
+			Handler:    _HelloService_DeleteHello_Handler,
+		},
+		{
+			MethodName: "ListHellos",
+			Handler:    _HelloService_ListHellos_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hello.proto",
+}
@@ -0,0 +1,250 @@
+// Code generated by protoc-gen-go-http. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-http v2.9.2
+// - protoc             v6.31.0
+// source: hello.proto
+
+package hellov1
+
+import (
+	context "context"
+	http "github.com/go-kratos/kratos/v2/transport/http"
+	binding "github.com/go-kratos/kratos/v2/transport/http/binding"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the kratos package it is being compiled against.
+var _ = new(context.Context)
+var _ = binding.EncodeURL
+
+const _ = http.SupportPackageIsVersion1
+
+const OperationHelloServiceCreateHello = "/gainetics.probe_executor.hello.v1.HelloService/CreateHello"
+const OperationHelloServiceDeleteHello = "/gainetics.probe_executor.hello.v1.HelloService/DeleteHello"
+const OperationHelloServiceGetHello = "/gainetics.probe_executor.hello.v1.HelloService/GetHello"
+const OperationHelloServiceListHellos = "/gainetics.probe_executor.hello.v1.HelloService/ListHellos"
+const OperationHelloServiceUpdateHello = "/gainetics.probe_executor.hello.v1.HelloService/UpdateHello"
+
+type HelloServiceHTTPServer interface {
+	// CreateHello 创建
+	CreateHello(context.Context, *CreateHelloRequest) (*Hello, error)
+	// DeleteHello 删除，不存在时返回 gRPC NotFound（HTTP 404）
+	DeleteHello(context.Context, *DeleteHelloRequest) (*emptypb.Empty, error)
+	// GetHello 按 ID 查询，不存在时返回 gRPC NotFound（HTTP 404）
+	GetHello(context.Context, *GetHelloRequest) (*Hello, error)
+	// ListHellos 分页列表，按 ID 倒序
+	ListHellos(context.Context, *ListHellosRequest) (*ListHellosReply, error)
+	// UpdateHello 更新，不存在时返回 gRPC NotFound（HTTP 404）
+	UpdateHello(context.Context, *UpdateHelloRequest) (*Hello, error)
+}
+
+func RegisterHelloServiceHTTPServer(s *http.Server, srv HelloServiceHTTPServer) {
+	r := s.Route("/")
+	r.POST("/apis/hello/v1/hellos", _HelloService_CreateHello0_HTTP_Handler(srv))
+	r.GET("/apis/hello/v1/hellos/{id}", _HelloService_GetHello0_HTTP_Handler(srv))
+	r.PUT("/apis/hello/v1/hellos/{id}", _HelloService_UpdateHello0_HTTP_Handler(srv))
+	r.DELETE("/apis/hello/v1/hellos/{id}", _HelloService_DeleteHello0_HTTP_Handler(srv))
+	r.GET("/apis/hello/v1/hellos", _HelloService_ListHellos0_HTTP_Handler(srv))
+}
+
+func _HelloService_CreateHello0_HTTP_Handler(srv HelloServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CreateHelloRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationHelloServiceCreateHello)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CreateHello(ctx, req.(*CreateHelloRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*Hello)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _HelloService_GetHello0_HTTP_Handler(srv HelloServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetHelloRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationHelloServiceGetHello)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetHello(ctx, req.(*GetHelloRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*Hello)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _HelloService_UpdateHello0_HTTP_Handler(srv HelloServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in UpdateHelloRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationHelloServiceUpdateHello)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.UpdateHello(ctx, req.(*UpdateHelloRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*Hello)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _HelloService_DeleteHello0_HTTP_Handler(srv HelloServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DeleteHelloRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationHelloServiceDeleteHello)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DeleteHello(ctx, req.(*DeleteHelloRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*emptypb.Empty)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _HelloService_ListHellos0_HTTP_Handler(srv HelloServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListHellosRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationHelloServiceListHellos)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListHellos(ctx, req.(*ListHellosRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListHellosReply)
+		return ctx.Result(200, reply)
+	}
+}
+
+type HelloServiceHTTPClient interface {
+	// CreateHello 创建
+	CreateHello(ctx context.Context, req *CreateHelloRequest, opts ...http.CallOption) (rsp *Hello, err error)
+	// DeleteHello 删除，不存在时返回 gRPC NotFound（HTTP 404）
+	DeleteHello(ctx context.Context, req *DeleteHelloRequest, opts ...http.CallOption) (rsp *emptypb.Empty, err error)
+	// GetHello 按 ID 查询，不存在时返回 gRPC NotFound（HTTP 404）
+	GetHello(ctx context.Context, req *GetHelloRequest, opts ...http.CallOption) (rsp *Hello, err error)
+	// ListHellos 分页列表，按 ID 倒序
+	ListHellos(ctx context.Context, req *ListHellosRequest, opts ...http.CallOption) (rsp *ListHellosReply, err error)
+	// UpdateHello 更新，不存在时返回 gRPC NotFound（HTTP 404）
+	UpdateHello(ctx context.Context, req *UpdateHelloRequest, opts ...http.CallOption) (rsp *Hello, err error)
+}
+
+type HelloServiceHTTPClientImpl struct {
+	cc *http.Client
+}
+
+func NewHelloServiceHTTPClient(client *http.Client) HelloServiceHTTPClient {
+	return &HelloServiceHTTPClientImpl{client}
+}
+
+// CreateHello 创建
+func (c *HelloServiceHTTPClientImpl) CreateHello(ctx context.Context, in *CreateHelloRequest, opts ...http.CallOption) (*Hello, error) {
+	var out Hello
+	pattern := "/apis/hello/v1/hellos"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationHelloServiceCreateHello))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteHello 删除，不存在时返回 gRPC NotFound（HTTP 404）
+func (c *HelloServiceHTTPClientImpl) DeleteHello(ctx context.Context, in *DeleteHelloRequest, opts ...http.CallOption) (*emptypb.Empty, error) {
+	var out emptypb.Empty
+	pattern := "/apis/hello/v1/hellos/{id}"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationHelloServiceDeleteHello))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "DELETE", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetHello 按 ID 查询，不存在时返回 gRPC NotFound（HTTP 404）
+func (c *HelloServiceHTTPClientImpl) GetHello(ctx context.Context, in *GetHelloRequest, opts ...http.CallOption) (*Hello, error) {
+	var out Hello
+	pattern := "/apis/hello/v1/hellos/{id}"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationHelloServiceGetHello))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListHellos 分页列表，按 ID 倒序
+func (c *HelloServiceHTTPClientImpl) ListHellos(ctx context.Context, in *ListHellosRequest, opts ...http.CallOption) (*ListHellosReply, error) {
+	var out ListHellosReply
+	pattern := "/apis/hello/v1/hellos"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationHelloServiceListHellos))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateHello 更新，不存在时返回 gRPC NotFound（HTTP 404）
+func (c *HelloServiceHTTPClientImpl) UpdateHello(ctx context.Context, in *UpdateHelloRequest, opts ...http.CallOption) (*Hello, error) {
+	var out Hello
+	pattern := "/apis/hello/v1/hellos/{id}"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationHelloServiceUpdateHello))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "PUT", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}